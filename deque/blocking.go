@@ -0,0 +1,131 @@
+package deque
+
+import (
+	"context"
+	"sync"
+)
+
+// BlockingDeque is a thread-safe deque for producer/consumer handoff: pops
+// block until an element is available and, when a capacity is set, pushes
+// block while the deque is full. Every blocking call takes a context, so
+// timeouts and cancellation come from context.WithTimeout and friends.
+type BlockingDeque[T any] struct {
+	mu       sync.Mutex
+	d        Deque[T]
+	capacity int           // maximum length, 0 if unbounded
+	changed  chan struct{} // closed on the next change, nil if nobody waits
+}
+
+// NewBlocking creates an empty blocking deque holding at most capacity
+// elements, or an unbounded one if capacity <= 0.
+func NewBlocking[T any](capacity int) *BlockingDeque[T] {
+	return &BlockingDeque[T]{capacity: max(capacity, 0)}
+}
+
+// PushBackCtx adds an element to the back, waiting while the deque is full.
+// It returns ctx.Err() if ctx is done first.
+func (b *BlockingDeque[T]) PushBackCtx(ctx context.Context, item T) error {
+	return b.push(ctx, item, false)
+}
+
+// PushFrontCtx adds an element to the front, waiting while the deque is
+// full. It returns ctx.Err() if ctx is done first.
+func (b *BlockingDeque[T]) PushFrontCtx(ctx context.Context, item T) error {
+	return b.push(ctx, item, true)
+}
+
+// PopFrontCtx removes and returns the front element, waiting until there is
+// one. It returns ctx.Err() if ctx is done first.
+func (b *BlockingDeque[T]) PopFrontCtx(ctx context.Context) (T, error) {
+	return b.pop(ctx, true)
+}
+
+// PopBackCtx removes and returns the back element, waiting until there is
+// one. It returns ctx.Err() if ctx is done first.
+func (b *BlockingDeque[T]) PopBackCtx(ctx context.Context) (T, error) {
+	return b.pop(ctx, false)
+}
+
+// TryPopFront removes and returns the front element without waiting.
+func (b *BlockingDeque[T]) TryPopFront() (T, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	v, ok := b.d.PopFront()
+	if ok {
+		b.signal()
+	}
+	return v, ok
+}
+
+// Len returns the number of elements.
+func (b *BlockingDeque[T]) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.d.Len()
+}
+
+// Cap returns the capacity given to NewBlocking, or 0 if unbounded.
+func (b *BlockingDeque[T]) Cap() int {
+	return b.capacity
+}
+
+func (b *BlockingDeque[T]) push(ctx context.Context, item T, atFront bool) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for b.capacity > 0 && b.d.Len() >= b.capacity {
+		if err := b.wait(ctx); err != nil {
+			return err
+		}
+	}
+	if atFront {
+		b.d.PushFront(item)
+	} else {
+		b.d.PushBack(item)
+	}
+	b.signal()
+	return nil
+}
+
+func (b *BlockingDeque[T]) pop(ctx context.Context, atFront bool) (T, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for b.d.Len() == 0 {
+		if err := b.wait(ctx); err != nil {
+			var zero T
+			return zero, err
+		}
+	}
+	var v T
+	if atFront {
+		v, _ = b.d.PopFront()
+	} else {
+		v, _ = b.d.PopBack()
+	}
+	b.signal()
+	return v, nil
+}
+
+// wait releases the lock until the deque changes or ctx is done, then
+// reacquires it. Callers must recheck their condition.
+func (b *BlockingDeque[T]) wait(ctx context.Context) error {
+	if b.changed == nil {
+		b.changed = make(chan struct{})
+	}
+	ch := b.changed
+	b.mu.Unlock()
+	defer b.mu.Lock()
+	select {
+	case <-ch:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// signal wakes every waiter.
+func (b *BlockingDeque[T]) signal() {
+	if b.changed != nil {
+		close(b.changed)
+		b.changed = nil
+	}
+}
@@ -0,0 +1,102 @@
+package deque
+
+// OverflowPolicy decides what a bounded deque does when an element is pushed
+// while it is full.
+type OverflowPolicy int
+
+const (
+	// Reject refuses the new element and leaves the deque unchanged.
+	Reject OverflowPolicy = iota
+	// DropOldest evicts the element at the opposite end from the push, the
+	// one that has waited longest, to make room.
+	DropOldest
+	// DropNewest evicts the element at the same end as the push, the one
+	// most recently pushed there, to make room.
+	DropNewest
+)
+
+// NewBounded creates an empty deque holding at most capacity elements,
+// handling overflow according to policy. The ring is allocated up front and
+// kept by Clear and TrimExcess, so a bounded deque only reallocates after
+// Release. It panics if capacity is not positive.
+func NewBounded[T any](capacity int, policy OverflowPolicy) *Deque[T] {
+	if capacity <= 0 {
+		panic("deque: bounded capacity must be positive")
+	}
+	d := &Deque[T]{limit: capacity, policy: policy}
+	d.resize(ceilPow2(capacity))
+	return d
+}
+
+// MaxLen returns the capacity given to NewBounded, or 0 if the deque is
+// unbounded.
+func (d *Deque[T]) MaxLen() int {
+	return d.limit
+}
+
+// IsFull reports whether a bounded deque holds MaxLen elements. It is always
+// false for an unbounded deque.
+func (d *Deque[T]) IsFull() bool {
+	return d.limit > 0 && d.len >= d.limit
+}
+
+// PushBackOverwrite adds an element to the back of a bounded deque, and if
+// the deque is full, overwrites the front element and returns it. This is
+// the drop-oldest ring buffer behaviour regardless of the overflow policy;
+// an unbounded deque never evicts.
+func (d *Deque[T]) PushBackOverwrite(item T) (T, bool) {
+	var evicted T
+	full := d.IsFull()
+	if full {
+		evicted, _ = d.PopFront()
+	}
+	d.TryPushBack(item)
+	return evicted, full
+}
+
+// PushFrontOverwrite is the mirror of PushBackOverwrite: on a full bounded
+// deque it overwrites the back element and returns it.
+func (d *Deque[T]) PushFrontOverwrite(item T) (T, bool) {
+	var evicted T
+	full := d.IsFull()
+	if full {
+		evicted, _ = d.PopBack()
+	}
+	d.TryPushFront(item)
+	return evicted, full
+}
+
+// makeRoom applies the overflow policy ahead of a push at the front or back
+// and reports whether the push may proceed.
+func (d *Deque[T]) makeRoom(atFront bool) bool {
+	if !d.IsFull() {
+		return true
+	}
+	switch d.policy {
+	case DropOldest:
+		if atFront {
+			d.PopBack()
+		} else {
+			d.PopFront()
+		}
+	case DropNewest:
+		if atFront {
+			d.PopFront()
+		} else {
+			d.PopBack()
+		}
+	default:
+		return false
+	}
+	return true
+}
+
+// ceilPow2 returns the smallest power of two that is at least n, and at
+// least minCapacity.
+func ceilPow2(n int) int {
+	c := minCapacity
+	for c < n {
+		c <<= 1
+	}
+	return c
+}
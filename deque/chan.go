@@ -0,0 +1,44 @@
+package deque
+
+import "context"
+
+// ToChan drains the deque into a new channel with the given buffer size,
+// front to back, from a separate goroutine. The channel is closed once the
+// deque is empty or ctx is done; an element is only removed from the deque
+// after it has been sent, so on cancellation the unsent elements stay.
+// The deque must not be used by anyone else until the channel is closed.
+func (d *Deque[T]) ToChan(ctx context.Context, buf int) <-chan T {
+	ch := make(chan T, max(buf, 0))
+	go func() {
+		defer close(ch)
+		for {
+			v, ok := d.PeekFront()
+			if !ok {
+				return
+			}
+			select {
+			case ch <- v:
+				d.PopFront()
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+// FromChan pushes every value received from ch to the back of the deque
+// until ch is closed, returning nil, or ctx is done, returning ctx.Err().
+func (d *Deque[T]) FromChan(ctx context.Context, ch <-chan T) error {
+	for {
+		select {
+		case v, ok := <-ch:
+			if !ok {
+				return nil
+			}
+			d.PushBack(v)
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
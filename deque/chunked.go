@@ -0,0 +1,192 @@
+package deque
+
+import (
+	"fmt"
+	"iter"
+	"strings"
+)
+
+// DefaultChunkSize is the number of elements per block used by NewChunked
+// when no block size is given.
+const DefaultChunkSize = 512
+
+// Chunked is a double-ended queue built from fixed-size blocks of elements,
+// for very large deques where Deque's doubling reallocation would copy
+// millions of elements at once. Growing only ever allocates one block and
+// moves block pointers, so push latency stays flat however long the deque
+// gets. The API mirrors the core of Deque.
+type Chunked[T any] struct {
+	blocks Deque[[]T] // ring of blocks, each of length size
+	size   int        // elements per block
+	head   int        // offset of the front element in the first block
+	len    int        // number of elements
+	spare  []T        // one zeroed block kept back to avoid churn at a boundary
+}
+
+// NewChunked creates a new empty chunked deque with blockSize elements per
+// block, or DefaultChunkSize if blockSize <= 0.
+func NewChunked[T any](blockSize int) *Chunked[T] {
+	if blockSize <= 0 {
+		blockSize = DefaultChunkSize
+	}
+	return &Chunked[T]{size: blockSize}
+}
+
+// PushBack adds an element to the back.
+func (c *Chunked[T]) PushBack(item T) {
+	pos := c.head + c.len
+	if pos == c.blocks.Len()*c.size {
+		c.blocks.PushBack(c.newBlock())
+	}
+	c.block(pos / c.size)[pos%c.size] = item
+	c.len++
+}
+
+// PushFront adds an element to the front.
+func (c *Chunked[T]) PushFront(item T) {
+	if c.head == 0 {
+		c.blocks.PushFront(c.newBlock())
+		c.head = c.size
+	}
+	c.head--
+	c.block(0)[c.head] = item
+	c.len++
+}
+
+// PopFront removes and returns the element at the front.
+func (c *Chunked[T]) PopFront() (T, bool) {
+	var zero T
+	if c.len == 0 {
+		return zero, false
+	}
+	b := c.block(0)
+	item := b[c.head]
+	b[c.head] = zero
+	c.head++
+	c.len--
+	if c.head == c.size {
+		c.spare, _ = c.blocks.PopFront()
+		c.head = 0
+	}
+	return item, true
+}
+
+// PopBack removes and returns the element at the back.
+func (c *Chunked[T]) PopBack() (T, bool) {
+	var zero T
+	if c.len == 0 {
+		return zero, false
+	}
+	c.len--
+	pos := c.head + c.len
+	b := c.block(pos / c.size)
+	item := b[pos%c.size]
+	b[pos%c.size] = zero
+	if pos%c.size == 0 {
+		c.spare, _ = c.blocks.PopBack()
+		if c.blocks.Len() == 0 {
+			c.head = 0
+		}
+	}
+	return item, true
+}
+
+// PeekFront returns the front element without removing.
+func (c *Chunked[T]) PeekFront() (T, bool) {
+	return c.At(0)
+}
+
+// PeekBack returns the back element without removing.
+func (c *Chunked[T]) PeekBack() (T, bool) {
+	return c.At(c.len - 1)
+}
+
+// At returns the element at index i, counting from the front.
+func (c *Chunked[T]) At(i int) (T, bool) {
+	if i < 0 || i >= c.len {
+		var zero T
+		return zero, false
+	}
+	pos := c.head + i
+	return c.block(pos / c.size)[pos%c.size], true
+}
+
+// Set replaces the element at index i, counting from the front, and reports
+// whether i was in range.
+func (c *Chunked[T]) Set(i int, item T) bool {
+	if i < 0 || i >= c.len {
+		return false
+	}
+	pos := c.head + i
+	c.block(pos / c.size)[pos%c.size] = item
+	return true
+}
+
+// Len returns the number of elements.
+func (c *Chunked[T]) Len() int {
+	return c.len
+}
+
+// IsEmpty returns true if empty.
+func (c *Chunked[T]) IsEmpty() bool {
+	return c.len == 0
+}
+
+// Clear removes all elements and releases the blocks.
+func (c *Chunked[T]) Clear() {
+	c.blocks.Clear()
+	c.head, c.len = 0, 0
+	c.spare = nil
+}
+
+// ToSlice returns a slice with the deque elements front to back.
+func (c *Chunked[T]) ToSlice() []T {
+	out := make([]T, 0, c.len)
+	for v := range c.All() {
+		out = append(out, v)
+	}
+	return out
+}
+
+// All returns an iterator over the elements from front to back.
+func (c *Chunked[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := 0; i < c.len; i++ {
+			pos := c.head + i
+			if !yield(c.block(pos / c.size)[pos%c.size]) {
+				return
+			}
+		}
+	}
+}
+
+// String implements fmt.Stringer
+func (c *Chunked[T]) String() string {
+	var sb strings.Builder
+	sb.WriteString("[")
+	i := 0
+	for v := range c.All() {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(fmt.Sprintf("%v", v))
+		i++
+	}
+	sb.WriteString("]")
+	return sb.String()
+}
+
+// block returns the k-th block counting from the front.
+func (c *Chunked[T]) block(k int) []T {
+	return c.blocks.buf[c.blocks.index(k)]
+}
+
+// newBlock returns the spare block if there is one, or a fresh block. Popped
+// slots are zeroed, so a block emptied by pops is ready for reuse.
+func (c *Chunked[T]) newBlock() []T {
+	if b := c.spare; b != nil {
+		c.spare = nil
+		return b
+	}
+	return make([]T, c.size)
+}
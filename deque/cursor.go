@@ -0,0 +1,108 @@
+package deque
+
+// Cursor walks a Deque in either direction and can edit it at the current
+// position. It stays valid across the mutations it performs itself, so
+// filtering or splicing while iterating needs no index arithmetic; any other
+// change to the deque invalidates it.
+//
+// A cursor is either on an element or in a gap between two elements (or
+// before the front, or after the back). It starts in a gap, and Remove
+// leaves it in the gap where the removed element was, so the following
+// Next or Prev visits the neighbour on that side.
+type Cursor[T any] struct {
+	d  *Deque[T]
+	i  int  // current element, or the element after the gap
+	on bool // whether the cursor is on element i
+}
+
+// Cursor returns a cursor positioned before the front element.
+func (d *Deque[T]) Cursor() *Cursor[T] {
+	return &Cursor[T]{d: d}
+}
+
+// CursorBack returns a cursor positioned after the back element.
+func (d *Deque[T]) CursorBack() *Cursor[T] {
+	return &Cursor[T]{d: d, i: d.len}
+}
+
+// Next moves to the next element and reports whether there was one. Past
+// the back, the cursor stays after the back element.
+func (c *Cursor[T]) Next() bool {
+	if c.on {
+		c.i++
+	}
+	c.on = c.i < c.d.len
+	if !c.on {
+		c.i = c.d.len
+	}
+	return c.on
+}
+
+// Prev moves to the previous element and reports whether there was one.
+// Before the front, the cursor stays before the front element.
+func (c *Cursor[T]) Prev() bool {
+	c.i--
+	c.on = c.i >= 0
+	if !c.on {
+		c.i = 0
+	}
+	return c.on
+}
+
+// Value returns the current element, or false if the cursor is in a gap.
+func (c *Cursor[T]) Value() (T, bool) {
+	if !c.on {
+		var zero T
+		return zero, false
+	}
+	return c.d.At(c.i)
+}
+
+// Index returns the index of the current element, or -1 if the cursor is in
+// a gap.
+func (c *Cursor[T]) Index() int {
+	if !c.on {
+		return -1
+	}
+	return c.i
+}
+
+// Set replaces the current element and reports whether there was one.
+func (c *Cursor[T]) Set(v T) bool {
+	return c.on && c.d.Set(c.i, v)
+}
+
+// Remove removes the current element, leaving the cursor in its place, and
+// reports whether there was one.
+func (c *Cursor[T]) Remove() bool {
+	if !c.on {
+		return false
+	}
+	c.d.RemoveAt(c.i)
+	c.on = false
+	return true
+}
+
+// InsertAfter inserts v after the current element, or into the gap, so that
+// Next visits it. It reports false without inserting if the deque is a full
+// bounded deque, since an eviction would move the cursor's position.
+func (c *Cursor[T]) InsertAfter(v T) bool {
+	if c.d.IsFull() {
+		return false
+	}
+	if c.on {
+		return c.d.Insert(c.i+1, v)
+	}
+	return c.d.Insert(c.i, v)
+}
+
+// InsertBefore inserts v before the current element, or into the gap, so
+// that Prev visits it. Like InsertAfter it refuses to insert into a full
+// bounded deque.
+func (c *Cursor[T]) InsertBefore(v T) bool {
+	if c.d.IsFull() || !c.d.Insert(c.i, v) {
+		return false
+	}
+	c.i++
+	return true
+}
@@ -1,18 +1,47 @@
 package deque
 
 import (
+	"cmp"
 	"fmt"
+	"iter"
+	"slices"
+	"sort"
 	"strings"
 )
 
+// minCapacity is the size of the first ring allocated by a growing deque.
+const minCapacity = 8
+
 // Deque is a generic, non-thread-safe double-ended queue.
+//
+// Elements live in a circular buffer whose length is always zero or a power
+// of two, so all four end operations are amortized O(1): the front and back
+// move by index arithmetic and the buffer only reallocates, doubling, when
+// it is full.
 type Deque[T any] struct {
-	items []T
+	buf    []T // ring storage
+	head   int // index in buf of the front element
+	len    int // number of elements
+	limit  int // maximum length, 0 if unbounded
+	policy OverflowPolicy
+	minCap int  // capacity floor, see SetMinCapacity
+	shrink int  // shrink divisor, 0 if disabled, see SetAutoShrink
+	shared bool // buf is shared with a Snapshot and must be copied before writes
+	stats  Stats
+	pool   *BufferPool[T] // buffer recycling, see NewPooled
 }
 
 // New creates a new empty deque.
 func New[T any]() *Deque[T] {
-	return &Deque[T]{items: make([]T, 0)}
+	return &Deque[T]{}
+}
+
+// NewWithCapacity creates a new empty deque with room for at least n
+// elements, so that the first n pushes do not reallocate.
+func NewWithCapacity[T any](n int) *Deque[T] {
+	d := &Deque[T]{}
+	d.Grow(n)
+	return d
 }
 
 func (d *Deque[T]) Push(item T) {
@@ -27,87 +56,753 @@ func (d *Deque[T]) Peek() (T, bool) {
 	return d.PeekBack()
 }
 
-// PushBack adds an element to the back.
+// PushBack adds an element to the back. On a full bounded deque the overflow
+// policy decides what happens; see TryPushBack.
 func (d *Deque[T]) PushBack(item T) {
-	d.items = append(d.items, item)
+	d.TryPushBack(item)
+}
+
+// TryPushBack adds an element to the back and reports whether it was stored.
+// It only returns false for a full bounded deque with the Reject policy.
+func (d *Deque[T]) TryPushBack(item T) bool {
+	if !d.makeRoom(false) {
+		return false
+	}
+	if d.len == len(d.buf) {
+		d.grow()
+	}
+	d.own()
+	d.buf[d.index(d.len)] = item
+	d.len++
+	d.pushed(false, 1)
+	return true
 }
 
-// PopBack removes and returns the element at the back.
+// PopBack removes and returns the element at the back. The vacated slot is
+// zeroed so the deque does not keep the element alive.
 func (d *Deque[T]) PopBack() (T, bool) {
-	if len(d.items) == 0 {
-		var zero T
+	var zero T
+	if d.len == 0 {
 		return zero, false
 	}
-	last := len(d.items) - 1
-	item := d.items[last]
-	d.items = d.items[:last]
+	d.own()
+	d.len--
+	d.stats.PopsBack++
+	i := d.index(d.len)
+	item := d.buf[i]
+	d.buf[i] = zero
+	d.maybeShrink()
 	return item, true
 }
 
-// PushFront adds an element to the front.
+// PushFront adds an element to the front. On a full bounded deque the
+// overflow policy decides what happens; see TryPushFront.
 func (d *Deque[T]) PushFront(item T) {
-	d.items = append([]T{item}, d.items...)
+	d.TryPushFront(item)
+}
+
+// TryPushFront adds an element to the front and reports whether it was
+// stored. It only returns false for a full bounded deque with the Reject
+// policy.
+func (d *Deque[T]) TryPushFront(item T) bool {
+	if !d.makeRoom(true) {
+		return false
+	}
+	if d.len == len(d.buf) {
+		d.grow()
+	}
+	d.own()
+	d.head = d.index(len(d.buf) - 1)
+	d.buf[d.head] = item
+	d.len++
+	d.pushed(true, 1)
+	return true
 }
 
-// PopFront removes and returns the element at the front.
+// PopFront removes and returns the element at the front. The vacated slot is
+// zeroed so the deque does not keep the element alive.
 func (d *Deque[T]) PopFront() (T, bool) {
-	if len(d.items) == 0 {
-		var zero T
+	var zero T
+	if d.len == 0 {
 		return zero, false
 	}
-	item := d.items[0]
-	d.items = d.items[1:]
+	d.own()
+	item := d.buf[d.head]
+	d.buf[d.head] = zero
+	d.head = d.index(1)
+	d.len--
+	d.stats.PopsFront++
+	d.maybeShrink()
 	return item, true
 }
 
+// PushBackAll adds items to the back in order, growing the buffer at most
+// once. A bounded deque applies its overflow policy to each item in turn.
+func (d *Deque[T]) PushBackAll(items ...T) {
+	if d.limit > 0 {
+		for _, item := range items {
+			d.TryPushBack(item)
+		}
+		return
+	}
+	d.Grow(len(items))
+	d.own()
+	d.copyIn(d.index(d.len), items)
+	d.len += len(items)
+	d.pushed(false, len(items))
+}
+
+// PushFrontAll adds items to the front keeping their order, so that items[0]
+// becomes the new front. A bounded deque applies its overflow policy to each
+// item in turn, last item first.
+func (d *Deque[T]) PushFrontAll(items ...T) {
+	if d.limit > 0 {
+		for i := len(items) - 1; i >= 0; i-- {
+			d.TryPushFront(items[i])
+		}
+		return
+	}
+	if len(items) == 0 {
+		return
+	}
+	d.Grow(len(items))
+	d.own()
+	d.head = d.index(len(d.buf) - len(items))
+	d.copyIn(d.head, items)
+	d.len += len(items)
+	d.pushed(true, len(items))
+}
+
+// PopFrontN removes up to n elements from the front and returns them front
+// to back.
+func (d *Deque[T]) PopFrontN(n int) []T {
+	return d.PopAppendFront(make([]T, 0, max(min(n, d.len), 0)), n)
+}
+
+// PopBackN removes up to n elements from the back and returns them front to
+// back, so that PushFrontAll(PopBackN(n)...) on another deque moves the
+// block without reordering it.
+func (d *Deque[T]) PopBackN(n int) []T {
+	return d.PopAppendBack(make([]T, 0, max(min(n, d.len), 0)), n)
+}
+
+// PopAppendFront is like PopFrontN but appends the removed elements to dst
+// and returns the extended slice, so a consumer loop can reuse one buffer.
+func (d *Deque[T]) PopAppendFront(dst []T, n int) []T {
+	n = max(min(n, d.len), 0)
+	dst = slices.Grow(dst, n)
+	d.copyOut(d.head, dst[len(dst):len(dst)+n])
+	d.own()
+	d.clearOut(d.head, n)
+	d.head = d.index(n)
+	d.len -= n
+	d.stats.PopsFront += uint64(n)
+	d.maybeShrink()
+	return dst[:len(dst)+n]
+}
+
+// PopAppendBack is like PopBackN but appends the removed elements, front to
+// back, to dst and returns the extended slice.
+func (d *Deque[T]) PopAppendBack(dst []T, n int) []T {
+	n = max(min(n, d.len), 0)
+	dst = slices.Grow(dst, n)
+	d.copyOut(d.index(d.len-n), dst[len(dst):len(dst)+n])
+	d.own()
+	d.clearOut(d.index(d.len-n), n)
+	d.len -= n
+	d.stats.PopsBack += uint64(n)
+	d.maybeShrink()
+	return dst[:len(dst)+n]
+}
+
+// DrainTo moves all elements to the back of dst, leaving d empty but with its
+// buffer intact. When dst is an empty unbounded deque the buffers are simply
+// exchanged. A bounded dst applies its overflow policy as for PushBackAll.
+func (d *Deque[T]) DrainTo(dst *Deque[T]) {
+	if dst == d || d.len == 0 {
+		return
+	}
+	if dst.len == 0 && dst.limit == 0 && len(dst.buf) <= len(d.buf) && len(dst.buf) >= d.minCap {
+		d.buf, dst.buf = dst.buf, d.buf
+		d.shared, dst.shared = dst.shared, d.shared
+		dst.head, dst.len = d.head, d.len
+		dst.pushed(false, d.len)
+		d.stats.PopsFront += uint64(d.len)
+		d.head, d.len = 0, 0
+		return
+	}
+	a, b := d.segments()
+	dst.PushBackAll(a...)
+	dst.PushBackAll(b...)
+	d.own()
+	a, b = d.segments()
+	clear(a)
+	clear(b)
+	d.stats.PopsFront += uint64(d.len)
+	d.head, d.len = 0, 0
+}
+
+// Drain returns an iterator that removes and yields the elements from front
+// to back. Stopping early leaves the remaining elements in place.
+func (d *Deque[T]) Drain() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for d.len > 0 {
+			v, _ := d.PopFront()
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
 // PeekFront returns the front element without removing.
 func (d *Deque[T]) PeekFront() (T, bool) {
-	if len(d.items) == 0 {
+	if d.len == 0 {
 		var zero T
 		return zero, false
 	}
-	return d.items[0], true
+	return d.buf[d.head], true
 }
 
 // PeekBack returns the back element without removing.
 func (d *Deque[T]) PeekBack() (T, bool) {
-	if len(d.items) == 0 {
+	if d.len == 0 {
+		var zero T
+		return zero, false
+	}
+	return d.buf[d.index(d.len-1)], true
+}
+
+// At returns the element at index i, counting from the front.
+func (d *Deque[T]) At(i int) (T, bool) {
+	if i < 0 || i >= d.len {
 		var zero T
 		return zero, false
 	}
-	return d.items[len(d.items)-1], true
+	return d.buf[d.index(i)], true
+}
+
+// Set replaces the element at index i, counting from the front, and reports
+// whether i was in range.
+func (d *Deque[T]) Set(i int, item T) bool {
+	if i < 0 || i >= d.len {
+		return false
+	}
+	d.own()
+	d.buf[d.index(i)] = item
+	return true
+}
+
+// Swap exchanges the elements at indexes i and j, counting from the front,
+// and reports whether both were in range.
+func (d *Deque[T]) Swap(i, j int) bool {
+	if i < 0 || i >= d.len || j < 0 || j >= d.len {
+		return false
+	}
+	d.own()
+	a, b := d.index(i), d.index(j)
+	d.buf[a], d.buf[b] = d.buf[b], d.buf[a]
+	return true
+}
+
+// Insert inserts item so that it ends up at index i, counting from the
+// front, and reports whether it was stored. i may equal Len to append. The
+// elements on the shorter side of i are shifted to make room. On a full
+// bounded deque the overflow policy applies as for PushBack.
+func (d *Deque[T]) Insert(i int, item T) bool {
+	if i < 0 || i > d.len {
+		return false
+	}
+	n := d.len
+	if !d.makeRoom(false) {
+		return false
+	}
+	if d.len < n {
+		if d.policy == DropOldest && i > 0 {
+			i--
+		}
+		i = min(i, d.len)
+	}
+	if d.len == len(d.buf) {
+		d.grow()
+	}
+	d.own()
+	if i < d.len/2 {
+		d.head = d.index(len(d.buf) - 1)
+		for j := 0; j < i; j++ {
+			d.buf[d.index(j)] = d.buf[d.index(j+1)]
+		}
+	} else {
+		for j := d.len; j > i; j-- {
+			d.buf[d.index(j)] = d.buf[d.index(j-1)]
+		}
+	}
+	d.buf[d.index(i)] = item
+	d.len++
+	d.stats.PeakLen = max(d.stats.PeakLen, d.len)
+	return true
+}
+
+// InsertSorted inserts v at its sorted position in a deque already sorted
+// by less, after any equal elements, and reports whether it was stored. The
+// position is found by binary search, and the elements on the shorter side
+// are shifted as for Insert.
+func (d *Deque[T]) InsertSorted(v T, less func(a, b T) bool) bool {
+	i := sort.Search(d.len, func(i int) bool { return less(v, d.buf[d.index(i)]) })
+	return d.Insert(i, v)
+}
+
+// RemoveAt removes and returns the element at index i, counting from the
+// front, shifting the elements on the shorter side of i to close the gap.
+func (d *Deque[T]) RemoveAt(i int) (T, bool) {
+	var zero T
+	if i < 0 || i >= d.len {
+		return zero, false
+	}
+	d.own()
+	item := d.buf[d.index(i)]
+	if i < d.len/2 {
+		for j := i; j > 0; j-- {
+			d.buf[d.index(j)] = d.buf[d.index(j-1)]
+		}
+		d.buf[d.head] = zero
+		d.head = d.index(1)
+	} else {
+		for j := i; j < d.len-1; j++ {
+			d.buf[d.index(j)] = d.buf[d.index(j+1)]
+		}
+		d.buf[d.index(d.len-1)] = zero
+	}
+	d.len--
+	d.maybeShrink()
+	return item, true
+}
+
+// RemoveIf removes every element that satisfies pred, keeping the order of
+// the rest, and returns how many were removed. The freed slots are zeroed so
+// removed elements can be garbage collected.
+func (d *Deque[T]) RemoveIf(pred func(T) bool) int {
+	d.own()
+	w := 0
+	for r := 0; r < d.len; r++ {
+		v := d.buf[d.index(r)]
+		if pred(v) {
+			continue
+		}
+		d.buf[d.index(w)] = v
+		w++
+	}
+	var zero T
+	for i := w; i < d.len; i++ {
+		d.buf[d.index(i)] = zero
+	}
+	removed := d.len - w
+	d.len = w
+	d.maybeShrink()
+	return removed
+}
+
+// Rotate rotates the deque left by n positions, so that the element at index
+// n becomes the front; a negative n rotates right. It moves at most
+// min(n, Len-n) elements, and none at all when the ring is full.
+func (d *Deque[T]) Rotate(n int) {
+	if d.len < 2 {
+		return
+	}
+	k := n % d.len
+	if k < 0 {
+		k += d.len
+	}
+	if k == 0 {
+		return
+	}
+	if d.len == len(d.buf) {
+		d.head = d.index(k)
+		return
+	}
+	d.own()
+	var zero T
+	if k <= d.len/2 {
+		for ; k > 0; k-- {
+			d.buf[d.index(d.len)] = d.buf[d.head]
+			d.buf[d.head] = zero
+			d.head = d.index(1)
+		}
+		return
+	}
+	for k = d.len - k; k > 0; k-- {
+		d.head = d.index(len(d.buf) - 1)
+		d.buf[d.head] = d.buf[d.index(d.len)]
+		d.buf[d.index(d.len)] = zero
+	}
+}
+
+// IndexFunc returns the index of the first element, counting from the
+// front, that satisfies pred, or -1.
+func (d *Deque[T]) IndexFunc(pred func(T) bool) int {
+	for i := 0; i < d.len; i++ {
+		if pred(d.buf[d.index(i)]) {
+			return i
+		}
+	}
+	return -1
+}
+
+// ContainsFunc reports whether any element satisfies pred.
+func (d *Deque[T]) ContainsFunc(pred func(T) bool) bool {
+	return d.IndexFunc(pred) >= 0
+}
+
+// MinFunc returns the first smallest element according to less, or false if
+// the deque is empty.
+func (d *Deque[T]) MinFunc(less func(a, b T) bool) (T, bool) {
+	if d.len == 0 {
+		var zero T
+		return zero, false
+	}
+	m := d.buf[d.head]
+	for i := 1; i < d.len; i++ {
+		if v := d.buf[d.index(i)]; less(v, m) {
+			m = v
+		}
+	}
+	return m, true
+}
+
+// MaxFunc returns the first largest element according to less, or false if
+// the deque is empty.
+func (d *Deque[T]) MaxFunc(less func(a, b T) bool) (T, bool) {
+	return d.MinFunc(func(a, b T) bool { return less(b, a) })
+}
+
+// Apply replaces each element with f applied to it, front to back.
+func (d *Deque[T]) Apply(f func(T) T) {
+	d.own()
+	for i := 0; i < d.len; i++ {
+		j := d.index(i)
+		d.buf[j] = f(d.buf[j])
+	}
+}
+
+// Reverse reverses the order of the elements in place.
+func (d *Deque[T]) Reverse() {
+	for i, j := 0, d.len-1; i < j; i, j = i+1, j-1 {
+		d.Swap(i, j)
+	}
+}
+
+// SortFunc sorts the elements front to back in place using the provided
+// less function. less(a, b) should return true if a < b.
+func (d *Deque[T]) SortFunc(less func(a, b T) bool) {
+	if d.len < 2 {
+		return
+	}
+	d.own()
+	d.linearize()
+	s := d.buf[:d.len]
+	sort.Slice(s, func(i, j int) bool { return less(s[i], s[j]) })
 }
 
 // Len returns the number of elements.
 func (d *Deque[T]) Len() int {
-	return len(d.items)
+	return d.len
 }
 
 // IsEmpty returns true if empty.
 func (d *Deque[T]) IsEmpty() bool {
-	return len(d.items) == 0
+	return d.len == 0
+}
+
+// Cap returns the number of elements the deque can hold before it has to
+// reallocate.
+func (d *Deque[T]) Cap() int {
+	return len(d.buf)
+}
+
+// Grow makes room for at least n more elements, so that the next n pushes
+// do not reallocate. Capacities are powers of two, so Grow may reserve more.
+func (d *Deque[T]) Grow(n int) {
+	if n > 0 && d.len+n > len(d.buf) {
+		d.resize(ceilPow2(d.len + n))
+	}
+}
+
+// TrimExcess shrinks the buffer to the smallest power of two that holds the
+// current elements and the capacity floor, returning memory left over from a
+// burst. An empty deque without a floor releases its buffer.
+func (d *Deque[T]) TrimExcess() {
+	need := max(d.len, d.floor())
+	if need == 0 {
+		d.free(d.buf)
+		d.buf, d.head = nil, 0
+		return
+	}
+	if c := ceilPow2(need); c < len(d.buf) {
+		d.resize(c)
+	}
+}
+
+// SetMinCapacity sets a capacity floor: the buffer is grown to at least n
+// now, and neither TrimExcess nor reallocation ever goes below it. Zero
+// removes the floor.
+func (d *Deque[T]) SetMinCapacity(n int) {
+	d.minCap = max(n, 0)
+	if d.minCap > len(d.buf) {
+		d.resize(ceilPow2(d.minCap))
+	}
 }
 
-// Clear removes all elements.
+// SetAutoShrink makes removals shrink the buffer once the deque has fallen
+// below 1/k of its capacity, so a long-lived deque does not keep a buffer
+// sized for its peak forever. Each step halves the buffer, never below the
+// SetMinCapacity floor, which keeps the cost amortized O(1) per removal.
+// Values of k below 4 are raised to 4 to avoid thrashing between growth and
+// shrinking; k <= 0 disables the policy. Bounded deques never shrink.
+func (d *Deque[T]) SetAutoShrink(k int) {
+	if k <= 0 {
+		d.shrink = 0
+		return
+	}
+	d.shrink = max(k, 4)
+}
+
+// Clear removes all elements. The buffer is dropped, or returned to the
+// BufferPool, so nothing in it stays reachable, and replaced by a fresh one
+// if the deque has a capacity floor.
 func (d *Deque[T]) Clear() {
-	d.items = make([]T, 0)
+	d.Release()
+	if f := d.floor(); f > 0 {
+		d.buf = d.alloc(ceilPow2(f))
+	}
+}
+
+// floor returns the capacity the buffer never goes below: the SetMinCapacity
+// floor, or a bounded deque's capacity.
+func (d *Deque[T]) floor() int {
+	return max(d.minCap, d.limit)
 }
+
+// ToArray returns a slice with the deque elements front to back.
 func (d *Deque[T]) ToArray() []T {
-	clone := make([]T, d.Len())
-	copy(clone, d.items)
-	return clone
+	return d.ToSlice()
+}
+
+// ToSlice returns a slice with the deque elements front to back.
+func (d *Deque[T]) ToSlice() []T {
+	out := make([]T, d.len)
+	d.copyTo(out)
+	return out
+}
+
+// FromSlice replaces the deque contents with elements from s, s[0] at the
+// front. A bounded deque applies its overflow policy as for PushBackAll.
+func (d *Deque[T]) FromSlice(s []T) {
+	d.Clear()
+	d.PushBackAll(s...)
+}
+
+// Clone returns a copy of the deque with its own buffer. Elements are copied
+// by assignment, and a bounded deque's capacity and policy carry over.
+func (d *Deque[T]) Clone() *Deque[T] {
+	c := *d
+	c.buf = d.alloc(len(d.buf))
+	c.shared = false
+	d.copyTo(c.buf)
+	c.head = 0
+	c.stats = Stats{PeakLen: d.len}
+	return &c
+}
+
+// EqualFunc reports whether d and other have the same length and eq holds
+// for each pair of elements at the same position. Capacity and layout of the
+// buffers do not matter.
+func (d *Deque[T]) EqualFunc(other *Deque[T], eq func(a, b T) bool) bool {
+	if d.len != other.len {
+		return false
+	}
+	for i := 0; i < d.len; i++ {
+		if !eq(d.buf[d.index(i)], other.buf[other.index(i)]) {
+			return false
+		}
+	}
+	return true
+}
+
+// All returns an iterator over the elements from front to back.
+func (d *Deque[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := 0; i < d.len; i++ {
+			if !yield(d.buf[d.index(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over the elements from back to front.
+func (d *Deque[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := d.len - 1; i >= 0 && i < d.len; i-- {
+			if !yield(d.buf[d.index(i)]) {
+				return
+			}
+		}
+	}
 }
 
 // String implements fmt.Stringer
 func (d *Deque[T]) String() string {
+	return d.FormatString(-1, nil)
+}
+
+// FormatString renders the deque like String but with at most max elements,
+// each formatted by f; the rest are summarized by count, e.g.
+// "[1, 2, 3, … +997]". A negative max means no limit and a nil f formats
+// elements with %v.
+func (d *Deque[T]) FormatString(max int, f func(T) string) string {
+	if f == nil {
+		f = func(v T) string { return fmt.Sprintf("%v", v) }
+	}
 	var sb strings.Builder
 	sb.WriteString("[")
-	for i, v := range d.items {
+	for i := 0; i < d.len; i++ {
 		if i > 0 {
 			sb.WriteString(", ")
 		}
-		sb.WriteString(fmt.Sprintf("%v", v))
+		if i == max {
+			sb.WriteString(fmt.Sprintf("… +%d", d.len-i))
+			break
+		}
+		sb.WriteString(f(d.buf[d.index(i)]))
 	}
 	sb.WriteString("]")
 	return sb.String()
 }
+
+// maybeShrink applies the SetAutoShrink policy after a removal.
+func (d *Deque[T]) maybeShrink() {
+	if d.shrink == 0 || d.limit > 0 {
+		return
+	}
+	n := len(d.buf)
+	for n/2 >= max(minCapacity, d.minCap) && d.len < n/d.shrink {
+		n /= 2
+	}
+	if n < len(d.buf) {
+		d.resize(n)
+	}
+}
+
+// index maps a front-relative position to an index in buf. It accepts any
+// i >= 0, wrapping around the ring.
+func (d *Deque[T]) index(i int) int {
+	return (d.head + i) & (len(d.buf) - 1)
+}
+
+// grow doubles the ring, moving the elements to the start of the new buffer.
+func (d *Deque[T]) grow() {
+	d.resize(ceilPow2(max(2*len(d.buf), d.minCap)))
+}
+
+// resize moves the elements into a new ring of size n, which must be a power
+// of two no smaller than d.len.
+func (d *Deque[T]) resize(n int) {
+	buf := d.alloc(n)
+	d.copyTo(buf)
+	d.free(d.buf)
+	d.buf = buf
+	d.head = 0
+	d.stats.Reallocs++
+}
+
+// copyTo copies the elements front to back into dst, which must have room
+// for d.len elements.
+func (d *Deque[T]) copyTo(dst []T) {
+	if d.len == 0 {
+		return
+	}
+	n := copy(dst[:d.len], d.buf[d.head:])
+	copy(dst[n:d.len], d.buf[:d.len-n])
+}
+
+// linearize rotates the ring in place so that the front element sits at
+// buf[0] and the elements occupy buf[:d.len] contiguously.
+func (d *Deque[T]) linearize() {
+	if d.head == 0 {
+		return
+	}
+	slices.Reverse(d.buf[:d.head])
+	slices.Reverse(d.buf[d.head:])
+	slices.Reverse(d.buf)
+	d.head = 0
+}
+
+// segments returns the elements as up to two slices of buf, front to back.
+func (d *Deque[T]) segments() (a, b []T) {
+	if d.head+d.len <= len(d.buf) {
+		return d.buf[d.head : d.head+d.len], nil
+	}
+	return d.buf[d.head:], d.buf[:d.head+d.len-len(d.buf)]
+}
+
+// clearOut zeroes n ring slots starting at buf index start, wrapping around
+// the end.
+func (d *Deque[T]) clearOut(start, n int) {
+	end := min(start+n, len(d.buf))
+	clear(d.buf[start:end])
+	clear(d.buf[:n-(end-start)])
+}
+
+// copyIn copies src into the ring starting at buf index start, wrapping
+// around the end. The ring must have room for src.
+func (d *Deque[T]) copyIn(start int, src []T) {
+	n := copy(d.buf[start:], src)
+	copy(d.buf, src[n:])
+}
+
+// copyOut copies len(dst) ring slots starting at buf index start into dst,
+// wrapping around the end.
+func (d *Deque[T]) copyOut(start int, dst []T) {
+	n := copy(dst, d.buf[start:])
+	copy(dst[n:], d.buf)
+}
+
+// Map returns a new unbounded deque holding f applied to each element of d,
+// in order. The result's ring is allocated once, at the size d needs.
+func Map[T, U any](d *Deque[T], f func(T) U) *Deque[U] {
+	out := &Deque[U]{}
+	if d.len > 0 {
+		out.buf = make([]U, ceilPow2(d.len))
+	}
+	for i := 0; i < d.len; i++ {
+		out.buf[i] = f(d.buf[d.index(i)])
+	}
+	out.len = d.len
+	out.stats.PeakLen = d.len
+	return out
+}
+
+// Min returns the smallest element of d, or false if d is empty.
+func Min[T cmp.Ordered](d *Deque[T]) (T, bool) {
+	return d.MinFunc(cmp.Less[T])
+}
+
+// Max returns the largest element of d, or false if d is empty.
+func Max[T cmp.Ordered](d *Deque[T]) (T, bool) {
+	return d.MaxFunc(cmp.Less[T])
+}
+
+// Equal reports whether a and b hold equal elements in the same order.
+func Equal[T comparable](a, b *Deque[T]) bool {
+	return a.EqualFunc(b, func(x, y T) bool { return x == y })
+}
+
+// Contains reports whether v is present in d.
+func Contains[T comparable](d *Deque[T], v T) bool {
+	return Index(d, v) >= 0
+}
+
+// Index returns the index of the first occurrence of v in d, or -1.
+func Index[T comparable](d *Deque[T], v T) int {
+	return d.IndexFunc(func(x T) bool { return x == v })
+}
@@ -1,8 +1,18 @@
 package deque
 
 import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"sync"
 	"testing"
+	"time"
 )
 
 func TestDeque(t *testing.T) {
@@ -61,3 +71,1148 @@ func TestPriorityQueue_String(t *testing.T) {
 	})
 	fmt.Println(pq)
 }
+
+func TestDequeRingWraparound(t *testing.T) {
+	dq := New[int]()
+	var ref []int
+	for i := 0; i < 1000; i++ {
+		switch i % 5 {
+		case 0, 1:
+			dq.PushBack(i)
+			ref = append(ref, i)
+		case 2:
+			dq.PushFront(i)
+			ref = append([]int{i}, ref...)
+		case 3:
+			v, _ := dq.PopFront()
+			if v != ref[0] {
+				t.Fatalf("PopFront: expected %v, got %v", ref[0], v)
+			}
+			ref = ref[1:]
+		case 4:
+			v, _ := dq.PopBack()
+			if v != ref[len(ref)-1] {
+				t.Fatalf("PopBack: expected %v, got %v", ref[len(ref)-1], v)
+			}
+			ref = ref[:len(ref)-1]
+		}
+	}
+	if fmt.Sprint(dq.ToArray()) != fmt.Sprint(ref) || dq.Len() != len(ref) {
+		t.Errorf("expected %v, got %v", ref, dq.ToArray())
+	}
+	for range ref {
+		dq.PopFront()
+	}
+	if _, ok := dq.PopFront(); ok || !dq.IsEmpty() {
+		t.Errorf("expected empty deque")
+	}
+}
+
+func TestBounded(t *testing.T) {
+	reject := NewBounded[int](2, Reject)
+	reject.PushBack(1)
+	reject.PushBack(2)
+	if reject.TryPushBack(3) || reject.TryPushFront(0) || !reject.IsFull() {
+		t.Errorf("expected pushes on a full Reject deque to fail")
+	}
+	if got := reject.String(); got != "[1, 2]" {
+		t.Errorf("expected [1, 2], got %v", got)
+	}
+
+	oldest := NewBounded[int](3, DropOldest)
+	for i := 1; i <= 5; i++ {
+		oldest.PushBack(i)
+	}
+	oldest.PushFront(0)
+	if got := oldest.String(); got != "[0, 3, 4]" {
+		t.Errorf("expected [0, 3, 4], got %v", got)
+	}
+
+	newest := NewBounded[int](3, DropNewest)
+	for i := 1; i <= 5; i++ {
+		newest.PushBack(i)
+	}
+	if got := newest.String(); got != "[1, 2, 5]" || newest.MaxLen() != 3 {
+		t.Errorf("expected [1, 2, 5], got %v", got)
+	}
+
+	ring := NewBounded[int](100, DropOldest)
+	ring.Clear()
+	ring.TrimExcess()
+	ring.FromSlice([]int{1, 2})
+	if err := ring.UnmarshalJSON([]byte("[3]")); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 100; i++ {
+		ring.PushBack(i)
+	}
+	if ring.Cap() != 128 || ring.Stats().Reallocs != 1 {
+		t.Errorf("expected the 128-slot ring to be kept, got %v after %d reallocations", ring.Cap(), ring.Stats().Reallocs)
+	}
+}
+
+func TestAtSet(t *testing.T) {
+	dq := New[int]()
+	for i := 1; i <= 10; i++ {
+		dq.PushBack(i)
+	}
+	dq.PopFront()
+	dq.PushFront(0) // front now wraps around the ring
+
+	if v, ok := dq.At(0); !ok || v != 0 {
+		t.Errorf("expected (0, true), got (%v, %v)", v, ok)
+	}
+	if !dq.Set(9, 99) || dq.Set(10, 1) || dq.Set(-1, 1) {
+		t.Errorf("unexpected Set bounds handling")
+	}
+	if v, _ := dq.At(9); v != 99 {
+		t.Errorf("expected 99, got %v", v)
+	}
+	if _, ok := dq.At(10); ok {
+		t.Errorf("expected At(10) to fail")
+	}
+}
+
+func TestRotate(t *testing.T) {
+	for _, size := range []int{5, 8} { // partially filled and full rings
+		for _, n := range []int{0, 1, 3, 4, -1, 12} {
+			dq := New[int]()
+			var ref []int
+			for i := 0; i < size; i++ {
+				dq.PushBack(i)
+				ref = append(ref, i)
+			}
+			dq.Rotate(n)
+			k := ((n % size) + size) % size
+			expected := append(append([]int{}, ref[k:]...), ref[:k]...)
+			if got := dq.ToArray(); fmt.Sprint(got) != fmt.Sprint(expected) {
+				t.Errorf("size %d Rotate(%d): expected %v, got %v", size, n, expected, got)
+			}
+		}
+	}
+}
+
+func TestIterators(t *testing.T) {
+	dq := New[int]()
+	dq.PushBack(2)
+	dq.PushBack(3)
+	dq.PushFront(1)
+	if got := slices.Collect(dq.All()); fmt.Sprint(got) != "[1 2 3]" {
+		t.Errorf("expected [1 2 3], got %v", got)
+	}
+	if got := slices.Collect(dq.Backward()); fmt.Sprint(got) != "[3 2 1]" {
+		t.Errorf("expected [3 2 1], got %v", got)
+	}
+	for v := range dq.All() {
+		if v == 2 {
+			break
+		}
+	}
+}
+
+func TestJSON(t *testing.T) {
+	type snapshot struct {
+		Events *Deque[string] `json:"events"`
+	}
+	in := snapshot{Events: New[string]()}
+	in.Events.PushBack("b")
+	in.Events.PushFront("a")
+
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if string(data) != `{"events":["a","b"]}` {
+		t.Errorf("unexpected JSON %s", data)
+	}
+	var out snapshot
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if got := out.Events.String(); got != "[a, b]" {
+		t.Errorf("expected [a, b], got %v", got)
+	}
+	if err := out.Events.UnmarshalJSON([]byte(`{}`)); err == nil || out.Events.Len() != 2 {
+		t.Errorf("expected error and unchanged deque")
+	}
+	if data, _ := json.Marshal(New[int]()); string(data) != "[]" {
+		t.Errorf("expected [], got %s", data)
+	}
+}
+
+func TestClone(t *testing.T) {
+	dq := NewBounded[int](4, DropOldest)
+	for i := 0; i < 6; i++ {
+		dq.PushBack(i)
+	}
+	c := dq.Clone()
+	dq.PushBack(6)
+	dq.Set(0, -1)
+
+	if got := c.String(); got != "[2, 3, 4, 5]" {
+		t.Errorf("expected [2, 3, 4, 5], got %v", got)
+	}
+	c.PushBack(7)
+	if got := c.String(); got != "[3, 4, 5, 7]" || c.MaxLen() != 4 {
+		t.Errorf("expected clone to stay bounded, got %v", got)
+	}
+}
+
+func TestInsertRemoveAt(t *testing.T) {
+	dq := New[int]()
+	var ref []int
+	for step := 0; step < 500; step++ {
+		if step%3 == 2 && len(ref) > 0 {
+			i := (step * 7) % len(ref)
+			v, ok := dq.RemoveAt(i)
+			if !ok || v != ref[i] {
+				t.Fatalf("RemoveAt(%d): expected %v, got %v", i, ref[i], v)
+			}
+			ref = slices.Delete(ref, i, i+1)
+		} else {
+			i := (step * 5) % (len(ref) + 1)
+			dq.Insert(i, step)
+			ref = slices.Insert(ref, i, step)
+		}
+	}
+	if !slices.Equal(dq.ToArray(), ref) {
+		t.Errorf("deque diverged from reference")
+	}
+	if dq.Insert(-1, 0) || dq.Insert(dq.Len()+1, 0) {
+		t.Errorf("expected out-of-range Insert to fail")
+	}
+	if _, ok := dq.RemoveAt(dq.Len()); ok {
+		t.Errorf("expected out-of-range RemoveAt to fail")
+	}
+
+	bounded := NewBounded[int](3, DropOldest)
+	bounded.PushBack(1)
+	bounded.PushBack(2)
+	bounded.PushBack(3)
+	bounded.Insert(2, 9)
+	if got := bounded.String(); got != "[2, 9, 3]" {
+		t.Errorf("expected [2, 9, 3], got %v", got)
+	}
+}
+
+func TestCapacity(t *testing.T) {
+	dq := New[int]()
+	dq.Grow(100)
+	if dq.Cap() < 100 {
+		t.Errorf("expected capacity >= 100, got %v", dq.Cap())
+	}
+	for i := 0; i < 1000; i++ {
+		dq.PushBack(i)
+	}
+	for i := 0; i < 990; i++ {
+		dq.PopFront()
+	}
+	dq.TrimExcess()
+	if dq.Cap() != 16 {
+		t.Errorf("expected capacity 16 after trim, got %v", dq.Cap())
+	}
+	if got := fmt.Sprint(dq.ToArray()); got != "[990 991 992 993 994 995 996 997 998 999]" {
+		t.Errorf("trim lost elements: %v", got)
+	}
+
+	dq.SetMinCapacity(64)
+	dq.Clear()
+	dq.TrimExcess()
+	if dq.Cap() != 64 {
+		t.Errorf("expected capacity floor 64, got %v", dq.Cap())
+	}
+	dq.SetMinCapacity(0)
+	dq.TrimExcess()
+	if dq.Cap() != 0 {
+		t.Errorf("expected buffer released, got %v", dq.Cap())
+	}
+}
+
+func TestSyncDeque(t *testing.T) {
+	s := NewSync[int]()
+	var wg sync.WaitGroup
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 250; i++ {
+				s.PushBack(i)
+				s.PushFront(-i)
+			}
+		}()
+	}
+	wg.Wait()
+	if s.Len() != 2000 {
+		t.Errorf("expected 2000 elements, got %v", s.Len())
+	}
+
+	s.Clear()
+	s.PushBack(1)
+	s.PushBack(2)
+	if _, ok := s.PopFrontIf(func(v int) bool { return v > 1 }); ok {
+		t.Errorf("expected PopFrontIf to decline")
+	}
+	if !s.SwapEnds() || s.String() != "[2, 1]" {
+		t.Errorf("expected [2, 1], got %v", s)
+	}
+	if v, ok := s.PopBackIf(func(v int) bool { return v == 1 }); !ok || v != 1 {
+		t.Errorf("expected (1, true), got (%v, %v)", v, ok)
+	}
+	s.Do(func(d *Deque[int]) { d.PushFront(0) })
+	if got := fmt.Sprint(s.ToArray()); got != "[0 2]" {
+		t.Errorf("expected [0 2], got %v", got)
+	}
+}
+
+func TestWorkStealing(t *testing.T) {
+	w := NewWorkStealing[int]()
+	for i := 0; i < 20; i++ {
+		w.Push(i)
+	}
+	if v, ok := w.Pop(); !ok || v != 19 {
+		t.Errorf("expected Pop (19, true), got (%v, %v)", v, ok)
+	}
+	if v, ok := w.Steal(); !ok || v != 0 {
+		t.Errorf("expected Steal (0, true), got (%v, %v)", v, ok)
+	}
+	for w.Len() > 0 {
+		w.Pop()
+	}
+	if _, ok := w.Steal(); ok {
+		t.Errorf("expected Steal on empty deque to fail")
+	}
+	slots := w.ring.Load().slots
+	for i := range slots {
+		if slots[i].Load() != nil {
+			t.Fatalf("expected drained slot %d to be cleared", i)
+		}
+	}
+
+	const n = 10000
+	seen := make([]int, n)
+	var mu sync.Mutex
+	record := func(v int) {
+		mu.Lock()
+		seen[v]++
+		mu.Unlock()
+	}
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	for k := 0; k < 4; k++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				if v, ok := w.Steal(); ok {
+					record(v)
+					continue
+				}
+				select {
+				case <-done:
+					return
+				default:
+				}
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		w.Push(i)
+		if i%3 == 0 {
+			if v, ok := w.Pop(); ok {
+				record(v)
+			}
+		}
+	}
+	for {
+		v, ok := w.Pop()
+		if !ok {
+			break
+		}
+		record(v)
+	}
+	close(done)
+	wg.Wait()
+	for v, c := range seen {
+		if c != 1 {
+			t.Fatalf("element %d taken %d times", v, c)
+		}
+	}
+}
+
+func TestBatchOperations(t *testing.T) {
+	d := New[int]()
+	d.PushBack(3)
+	d.PushBackAll(4, 5, 6, 7, 8, 9)
+	d.PushFrontAll(0, 1, 2)
+	if got := fmt.Sprint(d.ToArray()); got != "[0 1 2 3 4 5 6 7 8 9]" {
+		t.Errorf("expected [0 1 2 3 4 5 6 7 8 9], got %v", got)
+	}
+	if got := fmt.Sprint(d.PopFrontN(3)); got != "[0 1 2]" {
+		t.Errorf("expected PopFrontN [0 1 2], got %v", got)
+	}
+	if got := fmt.Sprint(d.PopBackN(2)); got != "[8 9]" {
+		t.Errorf("expected PopBackN [8 9], got %v", got)
+	}
+	if got := fmt.Sprint(d.PopBackN(10)); got != "[3 4 5 6 7]" {
+		t.Errorf("expected PopBackN [3 4 5 6 7], got %v", got)
+	}
+	if !d.IsEmpty() || len(d.PopFrontN(1)) != 0 {
+		t.Errorf("expected empty deque")
+	}
+
+	b := NewBounded[int](3, DropOldest)
+	b.PushBackAll(1, 2, 3, 4)
+	if got := fmt.Sprint(b.ToArray()); got != "[2 3 4]" {
+		t.Errorf("expected [2 3 4], got %v", got)
+	}
+}
+
+func TestIndexFunc(t *testing.T) {
+	d := New[int]()
+	d.PushBackAll(3, 4, 5)
+	d.PushFront(2)
+	if i := d.IndexFunc(func(v int) bool { return v > 3 }); i != 2 {
+		t.Errorf("expected IndexFunc 2, got %v", i)
+	}
+	if d.ContainsFunc(func(v int) bool { return v > 5 }) {
+		t.Errorf("expected ContainsFunc false")
+	}
+	if Index(d, 2) != 0 || Index(d, 9) != -1 {
+		t.Errorf("expected Index 0 and -1, got %v and %v", Index(d, 2), Index(d, 9))
+	}
+	if !Contains(d, 5) || Contains(New[int](), 0) {
+		t.Errorf("unexpected Contains result")
+	}
+}
+
+func TestReverse(t *testing.T) {
+	d := New[int]()
+	d.Reverse()
+	d.PushBackAll(3, 4, 5, 6, 7)
+	d.PushFrontAll(0, 1, 2)
+	d.Reverse()
+	if got := fmt.Sprint(d.ToArray()); got != "[7 6 5 4 3 2 1 0]" {
+		t.Errorf("expected [7 6 5 4 3 2 1 0], got %v", got)
+	}
+	d.PopBack()
+	d.Reverse()
+	if got := fmt.Sprint(d.ToArray()); got != "[1 2 3 4 5 6 7]" {
+		t.Errorf("expected [1 2 3 4 5 6 7], got %v", got)
+	}
+}
+
+func TestSortFunc(t *testing.T) {
+	d := New[int]()
+	d.PushBackAll(5, 1, 4)
+	d.PushFrontAll(8, 2, 7, 3)
+	d.SortFunc(func(a, b int) bool { return a < b })
+	if got := fmt.Sprint(d.ToArray()); got != "[1 2 3 4 5 7 8]" {
+		t.Errorf("expected [1 2 3 4 5 7 8], got %v", got)
+	}
+	d.PushFront(0)
+	d.PushBack(9)
+	if got := d.String(); got != "[0, 1, 2, 3, 4, 5, 7, 8, 9]" {
+		t.Errorf("expected [0, 1, 2, 3, 4, 5, 7, 8, 9], got %v", got)
+	}
+}
+
+func TestSwap(t *testing.T) {
+	d := New[int]()
+	d.PushBackAll(1, 2, 3)
+	d.PushFront(0)
+	if !d.Swap(0, 3) || !d.Swap(1, 1) {
+		t.Errorf("expected in-range swaps to succeed")
+	}
+	if d.Swap(-1, 0) || d.Swap(0, 4) {
+		t.Errorf("expected out-of-range swaps to fail")
+	}
+	if got := fmt.Sprint(d.ToArray()); got != "[3 1 2 0]" {
+		t.Errorf("expected [3 1 2 0], got %v", got)
+	}
+}
+
+func TestRemoveIf(t *testing.T) {
+	d := New[*int]()
+	for i := 0; i < 6; i++ {
+		v := i
+		d.PushFront(&v)
+	}
+	n := d.RemoveIf(func(p *int) bool { return *p%2 == 0 })
+	if n != 3 || d.Len() != 3 {
+		t.Errorf("expected 3 removed and 3 left, got %v and %v", n, d.Len())
+	}
+	var got []int
+	for p := range d.All() {
+		got = append(got, *p)
+	}
+	if fmt.Sprint(got) != "[5 3 1]" {
+		t.Errorf("expected [5 3 1], got %v", got)
+	}
+	for i := range d.buf {
+		if p := d.buf[i]; p != nil && *p%2 == 0 {
+			t.Errorf("removed element %v still referenced by buffer", *p)
+		}
+	}
+}
+
+func TestToSliceFromSlice(t *testing.T) {
+	d := New[int]()
+	d.PushBack(9)
+	src := []int{1, 2, 3}
+	d.FromSlice(src)
+	src[0] = 0
+	out := d.ToSlice()
+	if fmt.Sprint(out) != "[1 2 3]" {
+		t.Errorf("expected [1 2 3], got %v", out)
+	}
+	out[1] = 0
+	if got := d.String(); got != "[1, 2, 3]" {
+		t.Errorf("expected ToSlice to return a copy, got %v", got)
+	}
+	d.FromSlice(nil)
+	if !d.IsEmpty() {
+		t.Errorf("expected empty deque")
+	}
+}
+
+func TestMonotonic(t *testing.T) {
+	in := []int{1, 3, -1, -3, 5, 3, 6, 7}
+	m := NewMonotonic(func(a, b int) bool { return a < b })
+	var maxes []int
+	for i, v := range in {
+		m.Push(v)
+		if i >= 3 {
+			m.Shift()
+		}
+		if i >= 2 {
+			x, _ := m.Front()
+			maxes = append(maxes, x)
+		}
+	}
+	if got := fmt.Sprint(maxes); got != "[3 3 5 5 6 7]" {
+		t.Errorf("expected [3 3 5 5 6 7], got %v", got)
+	}
+	if m.Len() != 3 {
+		t.Errorf("expected window of 3, got %v", m.Len())
+	}
+
+	mins := NewMonotonic(func(a, b int) bool { return a > b })
+	mins.Push(2)
+	mins.Push(2)
+	mins.Push(4)
+	mins.Shift()
+	if v, ok := mins.Front(); !ok || v != 2 {
+		t.Errorf("expected min (2, true), got (%v, %v)", v, ok)
+	}
+	for mins.Shift() {
+	}
+	if _, ok := mins.Front(); ok || mins.Len() != 0 {
+		t.Errorf("expected empty window")
+	}
+}
+
+func TestDrain(t *testing.T) {
+	src := New[int]()
+	src.PushBackAll(3, 4, 5)
+	src.PushFrontAll(0, 1, 2)
+	dst := New[int]()
+	src.DrainTo(dst)
+	if !src.IsEmpty() || dst.String() != "[0, 1, 2, 3, 4, 5]" {
+		t.Errorf("expected [0, 1, 2, 3, 4, 5] moved, got %v and %v", src, dst)
+	}
+	src.PushBackAll(6, 7)
+	src.PushFront(-1)
+	src.DrainTo(dst)
+	if !src.IsEmpty() || dst.String() != "[0, 1, 2, 3, 4, 5, -1, 6, 7]" {
+		t.Errorf("expected [0, 1, 2, 3, 4, 5, -1, 6, 7], got %v", dst)
+	}
+	for _, v := range src.buf {
+		if v != 0 {
+			t.Errorf("expected drained slots to be zeroed, got %v", src.buf)
+			break
+		}
+	}
+
+	var got []int
+	for v := range dst.Drain() {
+		if v == 4 {
+			break
+		}
+		got = append(got, v)
+	}
+	if fmt.Sprint(got) != "[0 1 2 3]" || dst.String() != "[5, -1, 6, 7]" {
+		t.Errorf("expected [0 1 2 3] drained and [5, -1, 6, 7] left, got %v and %v", got, dst)
+	}
+}
+
+func TestChunked(t *testing.T) {
+	c := NewChunked[int](4)
+	for i := 0; i < 10; i++ {
+		c.PushBack(i)
+		c.PushFront(-i - 1)
+	}
+	if c.Len() != 20 {
+		t.Errorf("expected 20 elements, got %v", c.Len())
+	}
+	if v, _ := c.At(10); v != 0 {
+		t.Errorf("expected At(10) 0, got %v", v)
+	}
+	var got []int
+	for !c.IsEmpty() {
+		v, _ := c.PopFront()
+		got = append(got, v)
+		if v, ok := c.PopBack(); ok {
+			got = append(got, v)
+		}
+	}
+	want := "[-10 9 -9 8 -8 7 -7 6 -6 5 -5 4 -4 3 -3 2 -2 1 -1 0]"
+	if fmt.Sprint(got) != want {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	if _, ok := c.PopBack(); ok {
+		t.Errorf("expected PopBack on empty deque to fail")
+	}
+
+	c.PushFront(2)
+	c.PushFront(1)
+	c.PushBack(3)
+	c.Set(0, 0)
+	if c.String() != "[0, 2, 3]" || fmt.Sprint(c.ToSlice()) != "[0 2 3]" {
+		t.Errorf("expected [0, 2, 3], got %v", c)
+	}
+}
+
+func TestPushOverwrite(t *testing.T) {
+	d := NewBounded[int](3, Reject)
+	var evicted []int
+	for i := 1; i <= 5; i++ {
+		if v, ok := d.PushBackOverwrite(i); ok {
+			evicted = append(evicted, v)
+		}
+	}
+	if fmt.Sprint(evicted) != "[1 2]" || d.String() != "[3, 4, 5]" {
+		t.Errorf("expected [1 2] evicted and [3, 4, 5] kept, got %v and %v", evicted, d)
+	}
+	if v, ok := d.PushFrontOverwrite(2); !ok || v != 5 || d.String() != "[2, 3, 4]" {
+		t.Errorf("expected 5 evicted and [2, 3, 4] kept, got (%v, %v) and %v", v, ok, d)
+	}
+	u := New[int]()
+	if _, ok := u.PushBackOverwrite(1); ok || u.Len() != 1 {
+		t.Errorf("expected unbounded deque not to evict")
+	}
+}
+
+func TestFormatString(t *testing.T) {
+	d := New[int]()
+	for i := 1; i <= 1000; i++ {
+		d.PushBack(i)
+	}
+	if got := d.FormatString(3, nil); got != "[1, 2, 3, … +997]" {
+		t.Errorf("expected [1, 2, 3, … +997], got %v", got)
+	}
+	if got := d.FormatString(0, nil); got != "[… +1000]" {
+		t.Errorf("expected [… +1000], got %v", got)
+	}
+	d.FromSlice([]int{1, 2})
+	hex := func(v int) string { return fmt.Sprintf("%#x", v) }
+	if got := d.FormatString(2, hex); got != "[0x1, 0x2]" {
+		t.Errorf("expected [0x1, 0x2], got %v", got)
+	}
+}
+
+func TestEqualFunc(t *testing.T) {
+	a := New[int]()
+	a.PushBackAll(2, 3)
+	a.PushFront(1)
+	b := New[int]()
+	b.FromSlice([]int{1, 2, 3})
+	if !Equal(a, b) || !a.EqualFunc(b, func(x, y int) bool { return x == y }) {
+		t.Errorf("expected %v and %v to be equal", a, b)
+	}
+	b.Set(2, 5)
+	if Equal(a, b) {
+		t.Errorf("expected %v and %v to differ", a, b)
+	}
+	if !a.EqualFunc(b, func(x, y int) bool { return x%2 == y%2 }) {
+		t.Errorf("expected %v and %v to be equal in parity", a, b)
+	}
+	b.PopBack()
+	if a.EqualFunc(b, func(x, y int) bool { return true }) {
+		t.Errorf("expected different lengths to differ")
+	}
+}
+
+func TestBlockingDeque(t *testing.T) {
+	b := NewBlocking[int](2)
+	ctx := context.Background()
+	done := make(chan []int)
+	go func() {
+		var got []int
+		for i := 0; i < 5; i++ {
+			v, err := b.PopFrontCtx(ctx)
+			if err != nil {
+				t.Error(err)
+			}
+			got = append(got, v)
+		}
+		done <- got
+	}()
+	for i := 0; i < 5; i++ {
+		if err := b.PushBackCtx(ctx, i); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := fmt.Sprint(<-done); got != "[0 1 2 3 4]" {
+		t.Errorf("expected [0 1 2 3 4], got %v", got)
+	}
+
+	short, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if _, err := b.PopBackCtx(short); err != context.DeadlineExceeded {
+		t.Errorf("expected DeadlineExceeded from empty pop, got %v", err)
+	}
+	b.PushBackCtx(ctx, 1)
+	b.PushFrontCtx(ctx, 0)
+	if err := b.PushBackCtx(short, 2); err != context.DeadlineExceeded {
+		t.Errorf("expected DeadlineExceeded from full push, got %v", err)
+	}
+	if v, ok := b.TryPopFront(); !ok || v != 0 || b.Len() != 1 {
+		t.Errorf("expected (0, true) with one left, got (%v, %v)", v, ok)
+	}
+}
+
+func TestStats(t *testing.T) {
+	d := New[int]()
+	for i := 0; i < 10; i++ {
+		d.PushBack(i)
+	}
+	d.PushFrontAll(-2, -1)
+	d.PopFront()
+	d.PopBackN(3)
+	want := Stats{PushesFront: 2, PushesBack: 10, PopsFront: 1, PopsBack: 3, Len: 8, PeakLen: 12, Reallocs: 2}
+	if got := d.Stats(); got != want {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+	d.ResetStats()
+	if got := d.Stats(); got != (Stats{Len: 8, PeakLen: 8}) {
+		t.Errorf("expected reset stats, got %+v", got)
+	}
+	b := NewBounded[int](2, DropOldest)
+	b.PushBackAll(1, 2, 3)
+	if got := b.Stats(); got.PushesBack != 3 || got.PopsFront != 1 || got.PeakLen != 2 {
+		t.Errorf("expected eviction to count as a pop, got %+v", got)
+	}
+}
+
+func TestMapApply(t *testing.T) {
+	d := New[int]()
+	d.PushBackAll(2, 3)
+	d.PushFront(1)
+	d.Apply(func(v int) int { return v * 10 })
+	if got := d.String(); got != "[10, 20, 30]" {
+		t.Errorf("expected [10, 20, 30], got %v", got)
+	}
+	s := Map(d, func(v int) string { return fmt.Sprint("#", v) })
+	s.PushBack("end")
+	if got := s.String(); got != "[#10, #20, #30, end]" {
+		t.Errorf("expected [#10, #20, #30, end], got %v", got)
+	}
+	if e := Map(New[int](), func(v int) int { return v }); !e.IsEmpty() || e.Cap() != 0 {
+		t.Errorf("expected empty deque without buffer")
+	}
+}
+
+func TestPopAppend(t *testing.T) {
+	d := New[int]()
+	d.PushBackAll(3, 4, 5, 6)
+	d.PushFrontAll(0, 1, 2)
+	buf := make([]int, 0, 8)
+	buf = d.PopAppendFront(buf, 2)
+	buf = d.PopAppendBack(buf, 2)
+	if fmt.Sprint(buf) != "[0 1 5 6]" || d.String() != "[2, 3, 4]" {
+		t.Errorf("expected [0 1 5 6] and [2, 3, 4], got %v and %v", buf, d)
+	}
+	allocs := testing.AllocsPerRun(10, func() {
+		d.PushBackAll(1, 2)
+		buf = d.PopAppendFront(buf[:0], 2)
+	})
+	if allocs != 0 {
+		t.Errorf("expected no allocations, got %v", allocs)
+	}
+	buf = d.PopAppendBack(buf[:0], 10)
+	if len(buf) != 3 || !d.IsEmpty() {
+		t.Errorf("expected 3 elements popped, got %v", buf)
+	}
+}
+
+func TestPopClearsSlots(t *testing.T) {
+	d := New[*int]()
+	live := func() int {
+		n := 0
+		for _, p := range d.buf {
+			if p != nil {
+				n++
+			}
+		}
+		return n
+	}
+	for i := 0; i < 12; i++ {
+		v := i
+		d.PushBack(&v)
+	}
+	d.PopFront()
+	d.PopBack()
+	d.RemoveAt(1)
+	d.RemoveAt(7)
+	d.Rotate(3)
+	d.Rotate(-5)
+	d.PopFrontN(2)
+	d.PopAppendBack(nil, 2)
+	if live() != d.Len() {
+		t.Errorf("expected %d referenced slots, got %d", d.Len(), live())
+	}
+	d.Clear()
+	if d.buf != nil {
+		t.Errorf("expected Clear to drop the buffer")
+	}
+}
+
+func TestBufferPool(t *testing.T) {
+	p := NewBufferPool[*int]()
+	for round := 0; round < 3; round++ {
+		d := NewPooled(p)
+		for i := 0; i < 20; i++ {
+			v := i
+			d.PushBack(&v)
+		}
+		for i := 0; i < 5; i++ {
+			d.PopFront()
+		}
+		if d.Len() != 15 || *d.buf[d.head] != 5 {
+			t.Fatalf("round %d: unexpected contents %v", round, d)
+		}
+		n := 0
+		for _, e := range d.buf {
+			if e != nil {
+				n++
+			}
+		}
+		if n != d.Len() {
+			t.Fatalf("round %d: recycled buffer not zeroed", round)
+		}
+		d.Release()
+		if d.Len() != 0 || d.Cap() != 0 {
+			t.Errorf("expected released deque to be empty")
+		}
+	}
+	d := NewPooled(p)
+	d.SetMinCapacity(16)
+	d.Clear()
+	if d.Cap() != 16 || d.stats.Reallocs != 1 {
+		t.Errorf("expected 16-slot buffer after Clear, got %v", d.Cap())
+	}
+
+	e := NewPooled(NewBufferPool[int]())
+	if c := e.Clone(); c.Len() != 0 || c.Cap() != 0 {
+		t.Errorf("expected empty clone of an unallocated deque")
+	}
+	e.PushBack(1)
+	e.Release()
+	c := e.Clone()
+	c.PushBack(2)
+	if c.Len() != 1 || e.Len() != 0 {
+		t.Errorf("expected clone of a released deque to be independent")
+	}
+}
+
+func TestAutoShrink(t *testing.T) {
+	d := New[int]()
+	d.SetAutoShrink(4)
+	for i := 0; i < 1000; i++ {
+		d.PushBack(i)
+	}
+	if d.Cap() != 1024 {
+		t.Fatalf("expected capacity 1024, got %v", d.Cap())
+	}
+	d.PopFrontN(800)
+	if d.Cap() != 512 {
+		t.Errorf("expected capacity 512 after dropping below 1/4, got %v", d.Cap())
+	}
+	for d.Len() > 1 {
+		d.PopBack()
+	}
+	if d.Cap() != minCapacity {
+		t.Errorf("expected capacity %v, got %v", minCapacity, d.Cap())
+	}
+	if v, _ := d.PeekFront(); v != 800 {
+		t.Errorf("expected 800 to survive shrinking, got %v", v)
+	}
+
+	f := New[int]()
+	f.SetAutoShrink(4)
+	f.SetMinCapacity(100)
+	f.PushBackAll(make([]int, 1000)...)
+	f.RemoveIf(func(int) bool { return true })
+	if f.Cap() != 128 {
+		t.Errorf("expected shrinking to stop at the floor, got %v", f.Cap())
+	}
+}
+
+func TestBinaryEncoding(t *testing.T) {
+	d := New[string]()
+	d.PushBackAll("b", "c")
+	d.PushFront("a")
+	data, err := d.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := New[string]()
+	if err := out.UnmarshalBinary(data); err != nil || out.String() != "[a, b, c]" {
+		t.Errorf("expected [a, b, c], got %v (%v)", out, err)
+	}
+	if err := out.UnmarshalBinary(data[:3]); err != ErrInvalidEncoding {
+		t.Errorf("expected ErrInvalidEncoding, got %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(d); err != nil {
+		t.Fatal(err)
+	}
+	var g Deque[string]
+	if err := gob.NewDecoder(&buf).Decode(&g); err != nil || g.String() != "[a, b, c]" {
+		t.Errorf("expected gob round trip [a, b, c], got %v (%v)", &g, err)
+	}
+
+	codec := Codec[int]{
+		Marshal:   func(v int) ([]byte, error) { return []byte(strconv.Itoa(v)), nil },
+		Unmarshal: func(b []byte) (int, error) { return strconv.Atoi(string(b)) },
+	}
+	n := New[int]()
+	n.PushBackAll(7, 42, -1)
+	data, err = n.MarshalBinaryCodec(codec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := New[int]()
+	if err := m.UnmarshalBinaryCodec(data, codec); err != nil || m.String() != "[7, 42, -1]" {
+		t.Errorf("expected [7, 42, -1], got %v (%v)", m, err)
+	}
+	if err := m.UnmarshalBinary(data); err != ErrInvalidEncoding || m.Len() != 3 {
+		t.Errorf("expected ErrInvalidEncoding and unchanged deque, got %v", err)
+	}
+}
+
+func TestInsertSorted(t *testing.T) {
+	type job struct {
+		prio int
+		name string
+	}
+	less := func(a, b job) bool { return a.prio < b.prio }
+	d := New[job]()
+	for _, j := range []job{{3, "c"}, {1, "a"}, {2, "b"}, {1, "a2"}, {5, "e"}, {0, "z"}, {3, "c2"}} {
+		d.InsertSorted(j, less)
+	}
+	var names []string
+	for j := range d.All() {
+		names = append(names, j.name)
+	}
+	if got := fmt.Sprint(names); got != "[z a a2 b c c2 e]" {
+		t.Errorf("expected [z a a2 b c c2 e], got %v", got)
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	d := New[int]()
+	d.PushBackAll(1, 2, 3)
+	s := d.Snapshot()
+
+	var wg sync.WaitGroup
+	for r := 0; r < 4; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for k := 0; k < 100; k++ {
+				if got := fmt.Sprint(s.ToSlice()); got != "[1 2 3]" {
+					t.Errorf("expected snapshot [1 2 3], got %v", got)
+					return
+				}
+			}
+		}()
+	}
+	for i := 0; i < 100; i++ {
+		d.PopFront()
+		d.PushBack(i)
+		d.Set(0, -i)
+	}
+	wg.Wait()
+
+	s2 := d.Snapshot()
+	d.Reverse()
+	d.SortFunc(func(a, b int) bool { return a < b })
+	d.Clear()
+	if s.String() != "[1, 2, 3]" || s2.Len() != 3 || d.Len() != 0 {
+		t.Errorf("expected snapshots to be unaffected, got %v and %v", s, s2)
+	}
+	if v, ok := s2.At(2); !ok || v != 99 {
+		t.Errorf("expected (99, true), got (%v, %v)", v, ok)
+	}
+}
+
+func TestChanAdapters(t *testing.T) {
+	ctx := context.Background()
+	src := New[int]()
+	src.PushBackAll(1, 2, 3, 4)
+	dst := New[int]()
+	if err := dst.FromChan(ctx, src.ToChan(ctx, 1)); err != nil {
+		t.Fatal(err)
+	}
+	if !src.IsEmpty() || dst.String() != "[1, 2, 3, 4]" {
+		t.Errorf("expected [1, 2, 3, 4] moved, got %v and %v", src, dst)
+	}
+
+	cctx, cancel := context.WithCancel(ctx)
+	ch := dst.ToChan(cctx, 0)
+	if v := <-ch; v != 1 {
+		t.Errorf("expected 1, got %v", v)
+	}
+	cancel()
+	received := 1
+	for range ch {
+		received++
+	}
+	if received+dst.Len() != 4 {
+		t.Errorf("expected unsent elements to stay, got %d received and %v", received, dst)
+	}
+	if err := dst.FromChan(cctx, make(chan int)); err != context.Canceled {
+		t.Errorf("expected Canceled, got %v", err)
+	}
+}
+
+func TestNewWithCapacity(t *testing.T) {
+	d := NewWithCapacity[int](100)
+	if d.Cap() != 128 {
+		t.Errorf("expected capacity 128, got %v", d.Cap())
+	}
+	for i := 0; i < 100; i++ {
+		d.PushBack(i)
+	}
+	if d.Stats().Reallocs != 1 {
+		t.Errorf("expected a single allocation, got %v", d.Stats().Reallocs)
+	}
+	if e := NewWithCapacity[int](0); e.Cap() != 0 {
+		t.Errorf("expected no buffer for zero capacity, got %v", e.Cap())
+	}
+}
+
+func TestMinMax(t *testing.T) {
+	d := New[int]()
+	if _, ok := Min(d); ok {
+		t.Errorf("expected Min of empty deque to fail")
+	}
+	d.PushBackAll(4, 9, 1)
+	d.PushFrontAll(7, 1, 9)
+	if v, _ := Min(d); v != 1 {
+		t.Errorf("expected Min 1, got %v", v)
+	}
+	if v, _ := Max(d); v != 9 {
+		t.Errorf("expected Max 9, got %v", v)
+	}
+	type pair struct{ k, id int }
+	p := New[pair]()
+	p.PushBackAll(pair{2, 0}, pair{1, 1}, pair{2, 2}, pair{1, 3})
+	less := func(a, b pair) bool { return a.k < b.k }
+	if v, _ := p.MinFunc(less); v.id != 1 {
+		t.Errorf("expected first minimum id 1, got %v", v.id)
+	}
+	if v, _ := p.MaxFunc(less); v.id != 0 {
+		t.Errorf("expected first maximum id 0, got %v", v.id)
+	}
+}
+
+func TestSorter(t *testing.T) {
+	type rec struct{ k, id int }
+	d := New[rec]()
+	d.PushBackAll(rec{3, 0}, rec{1, 1}, rec{2, 2})
+	d.PushFrontAll(rec{1, 3}, rec{3, 4})
+	s := d.Sorter(func(a, b rec) bool { return a.k < b.k })
+	if sort.IsSorted(s) {
+		t.Errorf("expected unsorted deque")
+	}
+	sort.Stable(s)
+	if got := fmt.Sprint(d.ToSlice()); got != "[{1 3} {1 1} {2 2} {3 4} {3 0}]" {
+		t.Errorf("expected stable order [{1 3} {1 1} {2 2} {3 4} {3 0}], got %v", got)
+	}
+	i := sort.Search(d.Len(), func(i int) bool { v, _ := d.At(i); return v.k >= 2 })
+	if i != 2 {
+		t.Errorf("expected search index 2, got %v", i)
+	}
+}
+
+func TestErrorVariants(t *testing.T) {
+	d := NewBounded[int](2, Reject)
+	if _, err := d.PopFrontE(); !errors.Is(err, ErrEmpty) {
+		t.Errorf("expected ErrEmpty, got %v", err)
+	}
+	if _, err := d.PeekBackE(); !errors.Is(err, ErrEmpty) {
+		t.Errorf("expected ErrEmpty, got %v", err)
+	}
+	if err := d.PushBackE(2); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.PushFrontE(1); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.PushBackE(3); !errors.Is(err, ErrFull) {
+		t.Errorf("expected ErrFull, got %v", err)
+	}
+	if v, err := d.PeekFrontE(); err != nil || v != 1 {
+		t.Errorf("expected (1, nil), got (%v, %v)", v, err)
+	}
+	if v, err := d.PopBackE(); err != nil || v != 2 {
+		t.Errorf("expected (2, nil), got (%v, %v)", v, err)
+	}
+}
+
+func TestCursor(t *testing.T) {
+	d := New[int]()
+	d.PushBackAll(1, 2, 3, 4, 5, 6)
+	for c := d.Cursor(); c.Next(); {
+		v, _ := c.Value()
+		switch {
+		case v%2 == 0:
+			c.Remove()
+		case v == 3:
+			c.InsertAfter(33)
+			c.InsertBefore(-3)
+		}
+	}
+	if got := d.String(); got != "[1, -3, 3, 33, 5]" {
+		t.Errorf("expected [1, -3, 3, 33, 5], got %v", got)
+	}
+
+	var back []int
+	c := d.CursorBack()
+	for c.Prev() {
+		v, _ := c.Value()
+		back = append(back, v)
+		if v == 33 {
+			c.Set(34)
+		}
+	}
+	if fmt.Sprint(back) != "[5 33 3 -3 1]" || d.String() != "[1, -3, 3, 34, 5]" {
+		t.Errorf("expected [5 33 3 -3 1] and [1, -3, 3, 34, 5], got %v and %v", back, d)
+	}
+	if c.Index() != -1 || c.Remove() {
+		t.Errorf("expected cursor before the front")
+	}
+	c.InsertAfter(0)
+	c.Next()
+	c.Next()
+	c.Remove()
+	if !c.Prev() || c.Index() != 0 {
+		t.Errorf("expected Prev after Remove to visit the preceding element")
+	}
+	if got := d.String(); got != "[0, -3, 3, 34, 5]" {
+		t.Errorf("expected [0, -3, 3, 34, 5], got %v", got)
+	}
+}
@@ -0,0 +1,178 @@
+package deque
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Binary layout produced by MarshalBinary and MarshalBinaryCodec:
+//
+//	magic   [3]byte  "DEQ"
+//	version byte     currently 1
+//	kind    byte     0 for gob values, 1 for Codec records
+//	length  uvarint  number of elements
+//	values           front to back; either one gob stream, or per element a
+//	                 uvarint byte count followed by the Codec output
+//
+// The kind byte lets each decoder refuse data written by the other.
+const (
+	binaryMagic   = "DEQ"
+	binaryVersion = 1
+
+	kindGob   = 0
+	kindCodec = 1
+)
+
+// ErrInvalidEncoding is returned by the binary decoders when the input is
+// not a deque encoded by the matching marshal method.
+var ErrInvalidEncoding = errors.New("deque: invalid binary encoding")
+
+// Codec converts single elements to and from bytes, for element types gob
+// cannot handle or when a compact hand-written format is wanted.
+type Codec[T any] struct {
+	Marshal   func(T) ([]byte, error)
+	Unmarshal func([]byte) (T, error)
+}
+
+// MarshalJSON implements json.Marshaler, encoding the elements front to back
+// as a JSON array.
+func (d *Deque[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.ToArray())
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It replaces the contents with
+// the elements of a JSON array, pushed front to back, so a bounded deque
+// applies its overflow policy. On error the deque is left unchanged.
+func (d *Deque[T]) UnmarshalJSON(data []byte) error {
+	var items []T
+	if err := json.Unmarshal(data, &items); err != nil {
+		return err
+	}
+	d.Clear()
+	for _, item := range items {
+		d.PushBack(item)
+	}
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler. Elements are encoded
+// with encoding/gob, so T must be gob-encodable; see MarshalBinaryCodec for
+// other types. Capacity and overflow settings are not encoded.
+func (d *Deque[T]) MarshalBinary() ([]byte, error) {
+	buf := d.binaryHeader(kindGob)
+	enc := gob.NewEncoder(buf)
+	for v := range d.All() {
+		if err := enc.Encode(v); err != nil {
+			return nil, fmt.Errorf("deque: encode element: %w", err)
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. It replaces the
+// contents with the decoded elements as FromSlice does. On error the deque
+// is left unchanged.
+func (d *Deque[T]) UnmarshalBinary(data []byte) error {
+	data, n, err := readBinaryHeader(data, kindGob)
+	if err != nil {
+		return err
+	}
+	items := make([]T, 0, n)
+	dec := gob.NewDecoder(bytes.NewReader(data))
+	for i := uint64(0); i < n; i++ {
+		var v T
+		if err := dec.Decode(&v); err != nil {
+			return fmt.Errorf("deque: decode element %d: %w", i, err)
+		}
+		items = append(items, v)
+	}
+	d.FromSlice(items)
+	return nil
+}
+
+// MarshalBinaryCodec is like MarshalBinary but encodes each element with
+// c.Marshal. Decode the result with UnmarshalBinaryCodec.
+func (d *Deque[T]) MarshalBinaryCodec(c Codec[T]) ([]byte, error) {
+	buf := d.binaryHeader(kindCodec)
+	var lenBuf [binary.MaxVarintLen64]byte
+	for v := range d.All() {
+		b, err := c.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("deque: encode element: %w", err)
+		}
+		buf.Write(lenBuf[:binary.PutUvarint(lenBuf[:], uint64(len(b)))])
+		buf.Write(b)
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinaryCodec decodes data produced by MarshalBinaryCodec, decoding
+// each element with c.Unmarshal. On error the deque is left unchanged.
+func (d *Deque[T]) UnmarshalBinaryCodec(data []byte, c Codec[T]) error {
+	data, n, err := readBinaryHeader(data, kindCodec)
+	if err != nil {
+		return err
+	}
+	items := make([]T, 0, n)
+	for i := uint64(0); i < n; i++ {
+		size, k := binary.Uvarint(data)
+		if k <= 0 || size > uint64(len(data)-k) {
+			return ErrInvalidEncoding
+		}
+		v, err := c.Unmarshal(data[k : k+int(size)])
+		if err != nil {
+			return fmt.Errorf("deque: decode element %d: %w", i, err)
+		}
+		items = append(items, v)
+		data = data[k+int(size):]
+	}
+	d.FromSlice(items)
+	return nil
+}
+
+// GobEncode implements gob.GobEncoder using the MarshalBinary format.
+func (d *Deque[T]) GobEncode() ([]byte, error) { return d.MarshalBinary() }
+
+// GobDecode implements gob.GobDecoder using the UnmarshalBinary format.
+func (d *Deque[T]) GobDecode(data []byte) error { return d.UnmarshalBinary(data) }
+
+// binaryHeader returns a buffer holding the encoding header for d.
+func (d *Deque[T]) binaryHeader(kind byte) *bytes.Buffer {
+	var buf bytes.Buffer
+	buf.WriteString(binaryMagic)
+	buf.WriteByte(binaryVersion)
+	buf.WriteByte(kind)
+	var lenBuf [binary.MaxVarintLen64]byte
+	buf.Write(lenBuf[:binary.PutUvarint(lenBuf[:], uint64(d.len))])
+	return &buf
+}
+
+// readBinaryHeader validates the encoding header and returns the remaining
+// data and the element count.
+func readBinaryHeader(data []byte, kind byte) ([]byte, uint64, error) {
+	if len(data) < len(binaryMagic)+2 || string(data[:len(binaryMagic)]) != binaryMagic {
+		return nil, 0, ErrInvalidEncoding
+	}
+	data = data[len(binaryMagic):]
+	if v := data[0]; v == 0 || v > binaryVersion {
+		return nil, 0, fmt.Errorf("deque: unsupported encoding version %d", v)
+	}
+	if data[1] != kind {
+		return nil, 0, ErrInvalidEncoding
+	}
+	n, k := binary.Uvarint(data[2:])
+	if k <= 0 {
+		return nil, 0, ErrInvalidEncoding
+	}
+	data = data[2+k:]
+	// Every encoded element takes at least one byte, which bounds a corrupt
+	// length.
+	if n > uint64(len(data)) {
+		return nil, 0, ErrInvalidEncoding
+	}
+	return data, n, nil
+}
@@ -0,0 +1,55 @@
+package deque
+
+import "errors"
+
+var (
+	// ErrEmpty is returned by the error-returning accessors when the deque
+	// has no elements.
+	ErrEmpty = errors.New("deque: empty")
+	// ErrFull is returned by PushBackE and PushFrontE when a bounded deque
+	// with the Reject policy is full.
+	ErrFull = errors.New("deque: full")
+)
+
+// PopFrontE is like PopFront but returns ErrEmpty instead of false.
+func (d *Deque[T]) PopFrontE() (T, error) {
+	return orEmpty(d.PopFront())
+}
+
+// PopBackE is like PopBack but returns ErrEmpty instead of false.
+func (d *Deque[T]) PopBackE() (T, error) {
+	return orEmpty(d.PopBack())
+}
+
+// PeekFrontE is like PeekFront but returns ErrEmpty instead of false.
+func (d *Deque[T]) PeekFrontE() (T, error) {
+	return orEmpty(d.PeekFront())
+}
+
+// PeekBackE is like PeekBack but returns ErrEmpty instead of false.
+func (d *Deque[T]) PeekBackE() (T, error) {
+	return orEmpty(d.PeekBack())
+}
+
+// PushBackE is like TryPushBack but returns ErrFull instead of false.
+func (d *Deque[T]) PushBackE(item T) error {
+	if !d.TryPushBack(item) {
+		return ErrFull
+	}
+	return nil
+}
+
+// PushFrontE is like TryPushFront but returns ErrFull instead of false.
+func (d *Deque[T]) PushFrontE(item T) error {
+	if !d.TryPushFront(item) {
+		return ErrFull
+	}
+	return nil
+}
+
+func orEmpty[T any](v T, ok bool) (T, error) {
+	if !ok {
+		return v, ErrEmpty
+	}
+	return v, nil
+}
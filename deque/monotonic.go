@@ -0,0 +1,71 @@
+package deque
+
+// Monotonic is a monotonic queue for sliding-window extremes. It models a
+// window of values: Push appends the newest value and Shift drops the oldest,
+// while Front reports the greatest value in the window according to less in
+// O(1). With less(a, b) = a < b that is the window maximum; invert less for
+// the minimum.
+//
+// Internally only the values that can still become the answer are kept:
+// Push evicts every older value that is less than the new one, so each value
+// is stored and removed at most once and all operations are amortized O(1).
+type Monotonic[T any] struct {
+	d       Deque[monoEntry[T]]
+	less    func(a, b T) bool
+	pushed  uint64 // sequence number of the next Push
+	shifted uint64 // sequence number of the oldest value in the window
+}
+
+type monoEntry[T any] struct {
+	v   T
+	seq uint64
+}
+
+// NewMonotonic creates an empty monotonic queue ordered by less.
+func NewMonotonic[T any](less func(a, b T) bool) *Monotonic[T] {
+	return &Monotonic[T]{less: less}
+}
+
+// Push adds v as the newest value in the window.
+func (m *Monotonic[T]) Push(v T) {
+	for {
+		back, ok := m.d.PeekBack()
+		if !ok || !m.less(back.v, v) {
+			break
+		}
+		m.d.PopBack()
+	}
+	m.d.PushBack(monoEntry[T]{v: v, seq: m.pushed})
+	m.pushed++
+}
+
+// Shift drops the oldest value from the window and reports whether the
+// window was non-empty.
+func (m *Monotonic[T]) Shift() bool {
+	if m.shifted == m.pushed {
+		return false
+	}
+	if front, _ := m.d.PeekFront(); front.seq == m.shifted {
+		m.d.PopFront()
+	}
+	m.shifted++
+	return true
+}
+
+// Front returns the greatest value in the window.
+func (m *Monotonic[T]) Front() (T, bool) {
+	front, ok := m.d.PeekFront()
+	return front.v, ok
+}
+
+// Len returns the number of values in the window, including those that can
+// no longer be the answer.
+func (m *Monotonic[T]) Len() int {
+	return int(m.pushed - m.shifted)
+}
+
+// Clear empties the window.
+func (m *Monotonic[T]) Clear() {
+	m.d.Clear()
+	m.pushed, m.shifted = 0, 0
+}
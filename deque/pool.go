@@ -0,0 +1,71 @@
+package deque
+
+import (
+	"math/bits"
+	"sync"
+)
+
+// BufferPool recycles deque ring buffers through sync.Pools, one per
+// power-of-two size. A single pool may be shared by many deques of the same
+// element type, which pays off when deques are created and discarded at a
+// high rate, such as one per request.
+type BufferPool[T any] struct {
+	sizes [bits.UintSize]sync.Pool
+}
+
+// NewBufferPool returns an empty buffer pool.
+func NewBufferPool[T any]() *BufferPool[T] {
+	return &BufferPool[T]{}
+}
+
+// NewPooled returns an empty deque that takes its buffers from p and returns
+// outgrown ones to it. Call Release when done with the deque to hand back
+// the current buffer as well.
+func NewPooled[T any](p *BufferPool[T]) *Deque[T] {
+	return &Deque[T]{pool: p}
+}
+
+// Release empties the deque and returns its buffer to the deque's
+// BufferPool, if it has one. The deque stays usable.
+func (d *Deque[T]) Release() {
+	d.free(d.buf)
+	d.buf, d.head, d.len = nil, 0, 0
+}
+
+// get returns a zeroed buffer of length n, a power of two.
+func (p *BufferPool[T]) get(n int) []T {
+	if b, ok := p.sizes[bits.TrailingZeros(uint(n))].Get().(*[]T); ok {
+		return *b
+	}
+	return make([]T, n)
+}
+
+// put clears b and keeps it for reuse.
+func (p *BufferPool[T]) put(b []T) {
+	clear(b)
+	p.sizes[bits.TrailingZeros(uint(len(b)))].Put(&b)
+}
+
+// alloc returns a zeroed buffer of length n, from the pool if there is one.
+// A zero-length request returns nil, since the pool has no size class for it.
+func (d *Deque[T]) alloc(n int) []T {
+	if n == 0 {
+		return nil
+	}
+	if d.pool != nil {
+		return d.pool.get(n)
+	}
+	return make([]T, n)
+}
+
+// free hands a buffer that is no longer referenced back to the pool, unless
+// a Snapshot still shares it.
+func (d *Deque[T]) free(b []T) {
+	if d.shared {
+		d.shared = false
+		return
+	}
+	if d.pool != nil && len(b) > 0 {
+		d.pool.put(b)
+	}
+}
@@ -0,0 +1,89 @@
+package deque
+
+import (
+	"fmt"
+	"iter"
+	"strings"
+)
+
+// Snapshot is an immutable view of a Deque's contents at the time Snapshot
+// was called. Taking one is O(1): the snapshot shares the deque's buffer and
+// the deque copies the buffer before its next write. Readers may therefore
+// use a snapshot from any number of goroutines without locking while the
+// owner keeps mutating the deque.
+type Snapshot[T any] struct {
+	buf  []T
+	head int
+	len  int
+}
+
+// Snapshot returns an immutable view of the current contents.
+func (d *Deque[T]) Snapshot() *Snapshot[T] {
+	d.shared = len(d.buf) > 0
+	return &Snapshot[T]{buf: d.buf, head: d.head, len: d.len}
+}
+
+// Len returns the number of elements.
+func (s *Snapshot[T]) Len() int {
+	return s.len
+}
+
+// At returns the element at index i, counting from the front.
+func (s *Snapshot[T]) At(i int) (T, bool) {
+	if i < 0 || i >= s.len {
+		var zero T
+		return zero, false
+	}
+	return s.buf[s.index(i)], true
+}
+
+// All returns an iterator over the elements from front to back.
+func (s *Snapshot[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := 0; i < s.len; i++ {
+			if !yield(s.buf[s.index(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// ToSlice returns a slice with the elements front to back.
+func (s *Snapshot[T]) ToSlice() []T {
+	out := make([]T, 0, s.len)
+	for v := range s.All() {
+		out = append(out, v)
+	}
+	return out
+}
+
+// String implements fmt.Stringer
+func (s *Snapshot[T]) String() string {
+	var sb strings.Builder
+	sb.WriteString("[")
+	for i := 0; i < s.len; i++ {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(fmt.Sprintf("%v", s.buf[s.index(i)]))
+	}
+	sb.WriteString("]")
+	return sb.String()
+}
+
+func (s *Snapshot[T]) index(i int) int {
+	return (s.head + i) & (len(s.buf) - 1)
+}
+
+// own gives d a private copy of its buffer, with the same layout, if a
+// Snapshot shares it. Every write to d.buf must be preceded by a call.
+func (d *Deque[T]) own() {
+	if !d.shared {
+		return
+	}
+	d.shared = false
+	buf := d.alloc(len(d.buf))
+	copy(buf, d.buf)
+	d.buf = buf
+	d.stats.Reallocs++
+}
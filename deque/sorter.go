@@ -0,0 +1,24 @@
+package deque
+
+import "sort"
+
+// Sorter returns a sort.Interface over the deque's elements, ordered by
+// less, so sort.Sort, sort.Stable, sort.IsSorted and third-party code built
+// on sort.Interface can work on the deque in place.
+// The deque must not change length while the adapter is in use.
+func (d *Deque[T]) Sorter(less func(a, b T) bool) sort.Interface {
+	return sorter[T]{d: d, less: less}
+}
+
+type sorter[T any] struct {
+	d    *Deque[T]
+	less func(a, b T) bool
+}
+
+func (s sorter[T]) Len() int { return s.d.len }
+
+func (s sorter[T]) Less(i, j int) bool {
+	return s.less(s.d.buf[s.d.index(i)], s.d.buf[s.d.index(j)])
+}
+
+func (s sorter[T]) Swap(i, j int) { s.d.Swap(i, j) }
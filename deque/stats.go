@@ -0,0 +1,38 @@
+package deque
+
+// Stats holds usage counters for a Deque, for tuning capacities from real
+// workloads. Pushes and pops count elements moved through each end,
+// including batch operations and overflow evictions; positional edits such
+// as Insert and RemoveAt only show up in PeakLen.
+type Stats struct {
+	PushesFront uint64
+	PushesBack  uint64
+	PopsFront   uint64
+	PopsBack    uint64
+	Len         int    // current number of elements
+	PeakLen     int    // largest Len seen since creation or ResetStats
+	Reallocs    uint64 // number of times the buffer was reallocated
+}
+
+// Stats returns the deque's usage counters.
+func (d *Deque[T]) Stats() Stats {
+	s := d.stats
+	s.Len = d.len
+	return s
+}
+
+// ResetStats zeroes the counters and restarts PeakLen from the current
+// length.
+func (d *Deque[T]) ResetStats() {
+	d.stats = Stats{PeakLen: d.len}
+}
+
+// pushed records n elements pushed at one end.
+func (d *Deque[T]) pushed(atFront bool, n int) {
+	if atFront {
+		d.stats.PushesFront += uint64(n)
+	} else {
+		d.stats.PushesBack += uint64(n)
+	}
+	d.stats.PeakLen = max(d.stats.PeakLen, d.len)
+}
@@ -0,0 +1,142 @@
+package deque
+
+import "sync"
+
+// SyncDeque is a Deque guarded by a mutex, safe for concurrent use. Besides
+// the usual single operations it offers a few read-modify-write helpers that
+// would race if composed from separate calls, plus Do for anything else.
+type SyncDeque[T any] struct {
+	mu sync.Mutex
+	d  *Deque[T]
+}
+
+// NewSync creates a new empty thread-safe deque.
+func NewSync[T any]() *SyncDeque[T] {
+	return &SyncDeque[T]{d: New[T]()}
+}
+
+// NewSyncFrom wraps d. The caller must not use d directly afterwards.
+func NewSyncFrom[T any](d *Deque[T]) *SyncDeque[T] {
+	return &SyncDeque[T]{d: d}
+}
+
+// PushBack adds an element to the back.
+func (s *SyncDeque[T]) PushBack(item T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.d.PushBack(item)
+}
+
+// PushFront adds an element to the front.
+func (s *SyncDeque[T]) PushFront(item T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.d.PushFront(item)
+}
+
+// PopBack removes and returns the element at the back.
+func (s *SyncDeque[T]) PopBack() (T, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.d.PopBack()
+}
+
+// PopFront removes and returns the element at the front.
+func (s *SyncDeque[T]) PopFront() (T, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.d.PopFront()
+}
+
+// PeekFront returns the front element without removing.
+func (s *SyncDeque[T]) PeekFront() (T, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.d.PeekFront()
+}
+
+// PeekBack returns the back element without removing.
+func (s *SyncDeque[T]) PeekBack() (T, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.d.PeekBack()
+}
+
+// Len returns the number of elements.
+func (s *SyncDeque[T]) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.d.Len()
+}
+
+// IsEmpty returns true if empty.
+func (s *SyncDeque[T]) IsEmpty() bool {
+	return s.Len() == 0
+}
+
+// Clear removes all elements.
+func (s *SyncDeque[T]) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.d.Clear()
+}
+
+// ToArray returns a copy of the elements, front to back.
+func (s *SyncDeque[T]) ToArray() []T {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.d.ToArray()
+}
+
+// String implements fmt.Stringer
+func (s *SyncDeque[T]) String() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.d.String()
+}
+
+// PopFrontIf removes and returns the front element only if pred accepts it.
+func (s *SyncDeque[T]) PopFrontIf(pred func(T) bool) (T, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if v, ok := s.d.PeekFront(); !ok || !pred(v) {
+		var zero T
+		return zero, false
+	}
+	return s.d.PopFront()
+}
+
+// PopBackIf removes and returns the back element only if pred accepts it.
+func (s *SyncDeque[T]) PopBackIf(pred func(T) bool) (T, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if v, ok := s.d.PeekBack(); !ok || !pred(v) {
+		var zero T
+		return zero, false
+	}
+	return s.d.PopBack()
+}
+
+// SwapEnds exchanges the front and back elements and reports whether the
+// deque held at least two elements.
+func (s *SyncDeque[T]) SwapEnds() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := s.d.Len()
+	if n < 2 {
+		return false
+	}
+	front, _ := s.d.At(0)
+	back, _ := s.d.At(n - 1)
+	s.d.Set(0, back)
+	s.d.Set(n-1, front)
+	return true
+}
+
+// Do runs f with exclusive access to the underlying deque, making any
+// sequence of operations atomic. f must not retain d after returning.
+func (s *SyncDeque[T]) Do(f func(d *Deque[T])) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f(s.d)
+}
@@ -0,0 +1,114 @@
+package deque
+
+import "sync/atomic"
+
+// WorkStealing is a Chase–Lev work-stealing deque. One goroutine, the owner,
+// pushes and pops at the bottom without locking; any number of other
+// goroutines may concurrently Steal from the top. This is the classic
+// building block for task schedulers: each worker keeps its own deque and
+// idle workers steal the oldest work of busy ones.
+//
+// Push and Pop must only be called by the owner. Steal and Len are safe from
+// any goroutine.
+type WorkStealing[T any] struct {
+	top    atomic.Int64
+	bottom atomic.Int64
+	ring   atomic.Pointer[wsRing[T]]
+}
+
+// wsRing is the growable circular array behind WorkStealing. Slots hold
+// pointers so that a thief reading a slot never races with the owner writing
+// a different generation of it.
+type wsRing[T any] struct {
+	slots []atomic.Pointer[T]
+}
+
+// NewWorkStealing creates a new empty work-stealing deque.
+func NewWorkStealing[T any]() *WorkStealing[T] {
+	w := &WorkStealing[T]{}
+	w.ring.Store(&wsRing[T]{slots: make([]atomic.Pointer[T], minCapacity)})
+	return w
+}
+
+// Push adds an element to the bottom. Owner only.
+func (w *WorkStealing[T]) Push(item T) {
+	b := w.bottom.Load()
+	t := w.top.Load()
+	r := w.ring.Load()
+	if b-t >= int64(len(r.slots)) {
+		r = r.grow(t, b)
+		w.ring.Store(r)
+	}
+	r.put(b, &item)
+	w.bottom.Store(b + 1)
+}
+
+// Pop removes and returns the most recently pushed element. Owner only.
+func (w *WorkStealing[T]) Pop() (T, bool) {
+	var zero T
+	b := w.bottom.Load() - 1
+	r := w.ring.Load()
+	w.bottom.Store(b)
+	t := w.top.Load()
+	if t > b {
+		w.bottom.Store(b + 1)
+		return zero, false
+	}
+	item := r.get(b)
+	if t == b {
+		// Last element: race any thief for it.
+		won := w.top.CompareAndSwap(t, t+1)
+		w.bottom.Store(b + 1)
+		if !won {
+			return zero, false
+		}
+	}
+	// No thief can reach slot b any more, so drop the reference to let the
+	// popped element be collected.
+	r.put(b, nil)
+	return *item, true
+}
+
+// Steal removes and returns the oldest element. It may be called from any
+// goroutine and only reports false when the deque is empty.
+func (w *WorkStealing[T]) Steal() (T, bool) {
+	for {
+		t := w.top.Load()
+		b := w.bottom.Load()
+		if t >= b {
+			var zero T
+			return zero, false
+		}
+		r := w.ring.Load()
+		item := r.get(t)
+		if w.top.CompareAndSwap(t, t+1) {
+			// Clear the slot unless the owner has already reused it.
+			r.slots[t&int64(len(r.slots)-1)].CompareAndSwap(item, nil)
+			return *item, true
+		}
+	}
+}
+
+// Len returns the number of elements. Under concurrent use the result is
+// only a snapshot.
+func (w *WorkStealing[T]) Len() int {
+	n := w.bottom.Load() - w.top.Load()
+	return int(max(n, 0))
+}
+
+func (r *wsRing[T]) get(i int64) *T {
+	return r.slots[i&int64(len(r.slots)-1)].Load()
+}
+
+func (r *wsRing[T]) put(i int64, v *T) {
+	r.slots[i&int64(len(r.slots)-1)].Store(v)
+}
+
+// grow returns a ring of twice the size holding the elements in [t, b).
+func (r *wsRing[T]) grow(t, b int64) *wsRing[T] {
+	n := &wsRing[T]{slots: make([]atomic.Pointer[T], 2*len(r.slots))}
+	for i := t; i < b; i++ {
+		n.put(i, r.get(i))
+	}
+	return n
+}
@@ -0,0 +1,69 @@
+package dll
+
+// DefaultArenaBlock is the slab size used by NewArena.
+const DefaultArenaBlock = 256
+
+// nodeArena hands out nodes from slabs allocated blockSize at a time and
+// keeps removed nodes on a free list for reuse.
+type nodeArena[T any] struct {
+	block []Node[T] // unused remainder of the current slab
+	free  *Node[T]  // released nodes, chained through next
+}
+
+// WithArena makes the list allocate its nodes from slabs of blockSize nodes,
+// so building a large list costs one allocation per slab instead of one per
+// node, and Clear releases everything at once. Removed nodes are reused by
+// later insertions of the same list. WithArena takes precedence over
+// WithNodePool.
+//
+// As in pooled mode, a node must not be used once it has been removed from
+// the list. Memory is returned to the runtime only when Clear drops the
+// slabs, since a slab stays alive while any of its nodes is referenced.
+func WithArena[T any](blockSize int) Option[T] {
+	return func(l *List[T]) { l.cfg.arenaBlock = blockSize }
+}
+
+// NewArena returns an empty list using slabs of DefaultArenaBlock nodes,
+// further configured by opts.
+func NewArena[T any](opts ...Option[T]) *List[T] {
+	return New(append([]Option[T]{WithArena[T](DefaultArenaBlock)}, opts...)...)
+}
+
+func (l *List[T]) arenaAlloc() *Node[T] {
+	if l.mem == nil {
+		l.mem = &nodeArena[T]{}
+	}
+	a := l.mem
+	if n := a.free; n != nil {
+		a.free = n.next
+		n.next = nil
+		return n
+	}
+	if len(a.block) == 0 {
+		a.block = make([]Node[T], l.cfg.arenaBlock)
+	}
+	n := &a.block[0]
+	a.block = a.block[1:]
+	return n
+}
+
+func (l *List[T]) arenaFree(n *Node[T]) {
+	if l.mem == nil {
+		l.mem = &nodeArena[T]{}
+	}
+	n.next = l.mem.free
+	l.mem.free = n
+}
+
+// resetArena empties the list in O(1). Instead of visiting each node it
+// retires the list's identity, so stale nodes no longer count as members,
+// and drops the slabs.
+func (l *List[T]) resetArena() {
+	if l.id != nil {
+		l.id.list = nil
+		l.id = nil
+	}
+	l.mem = nil
+	l.head, l.tail, l.len = nil, nil, 0
+	l.mod++
+}
@@ -0,0 +1,50 @@
+package dll
+
+// Compat adapts a List to the method set of container/list, so code written
+// against container/list can switch to typed values by changing its imports
+// and type names. Methods whose signatures agree are promoted from List;
+// the ones below follow container/list where they differ.
+type Compat[T any] struct {
+	*List[T]
+}
+
+// NewCompat returns an empty container/list compatible list.
+func NewCompat[T any]() *Compat[T] { return &Compat[T]{List: New[T]()} }
+
+// Init clears the list and returns it.
+func (c *Compat[T]) Init() *Compat[T] {
+	c.Clear()
+	return c
+}
+
+// InsertBefore inserts v before mark and returns the new node. If mark is
+// nil or not in the list, the list is unchanged and nil is returned.
+func (c *Compat[T]) InsertBefore(v T, mark *Node[T]) *Node[T] {
+	if mark == nil {
+		return nil
+	}
+	return c.List.InsertBefore(mark, v)
+}
+
+// InsertAfter inserts v after mark and returns the new node. If mark is nil
+// or not in the list, the list is unchanged and nil is returned.
+func (c *Compat[T]) InsertAfter(v T, mark *Node[T]) *Node[T] {
+	if mark == nil {
+		return nil
+	}
+	return c.List.InsertAfter(mark, v)
+}
+
+// Remove removes e if it belongs to the list and returns e.Value.
+func (c *Compat[T]) Remove(e *Node[T]) T {
+	if v, ok := c.List.Remove(e); ok {
+		return v
+	}
+	return e.Value
+}
+
+// PushBackList inserts a copy of other's values at the back of the list.
+func (c *Compat[T]) PushBackList(other *Compat[T]) { c.List.PushBackList(other.List) }
+
+// PushFrontList inserts a copy of other's values at the front of the list.
+func (c *Compat[T]) PushFrontList(other *Compat[T]) { c.List.PushFrontList(other.List) }
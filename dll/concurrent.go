@@ -0,0 +1,132 @@
+package dll
+
+import (
+	"cmp"
+	"iter"
+	"sync"
+	"sync/atomic"
+)
+
+// ConcurrentList is a sorted set of ordered values that is safe for
+// concurrent use. It implements the lazy list algorithm of Heller et al.:
+// Insert and Remove lock only the two nodes around the affected position and
+// validate them after locking, removal first marks a node as deleted and then
+// unlinks it, and Contains takes no locks at all. Operations on different
+// parts of the list therefore proceed in parallel.
+//
+// Unlike List it is singly linked and keyed by value; it trades positional
+// operations for scalability under contention.
+type ConcurrentList[T cmp.Ordered] struct {
+	head cnode[T] // sentinel; its value is never compared
+	len  atomic.Int64
+}
+
+type cnode[T cmp.Ordered] struct {
+	value  T
+	mu     sync.Mutex
+	marked atomic.Bool // logically deleted
+	next   atomic.Pointer[cnode[T]]
+}
+
+// NewConcurrent returns an empty concurrent list.
+func NewConcurrent[T cmp.Ordered]() *ConcurrentList[T] { return &ConcurrentList[T]{} }
+
+// Len returns the number of values. Under concurrent modification the result
+// is a momentary snapshot.
+func (c *ConcurrentList[T]) Len() int { return int(c.len.Load()) }
+
+// Insert adds v and reports whether it was absent.
+func (c *ConcurrentList[T]) Insert(v T) bool {
+	for {
+		pred, curr := c.locate(v)
+		lockPair(pred, curr)
+		if validPair(pred, curr) {
+			if curr != nil && cmp.Compare(curr.value, v) == 0 {
+				unlockPair(pred, curr)
+				return false
+			}
+			n := &cnode[T]{value: v}
+			n.next.Store(curr)
+			pred.next.Store(n)
+			c.len.Add(1)
+			unlockPair(pred, curr)
+			return true
+		}
+		unlockPair(pred, curr)
+	}
+}
+
+// Remove deletes v and reports whether it was present.
+func (c *ConcurrentList[T]) Remove(v T) bool {
+	for {
+		pred, curr := c.locate(v)
+		lockPair(pred, curr)
+		if validPair(pred, curr) {
+			if curr == nil || cmp.Compare(curr.value, v) != 0 {
+				unlockPair(pred, curr)
+				return false
+			}
+			curr.marked.Store(true)
+			pred.next.Store(curr.next.Load())
+			c.len.Add(-1)
+			unlockPair(pred, curr)
+			return true
+		}
+		unlockPair(pred, curr)
+	}
+}
+
+// Contains reports whether v is present. It never blocks.
+func (c *ConcurrentList[T]) Contains(v T) bool {
+	curr := c.head.next.Load()
+	for curr != nil && cmp.Less(curr.value, v) {
+		curr = curr.next.Load()
+	}
+	return curr != nil && cmp.Compare(curr.value, v) == 0 && !curr.marked.Load()
+}
+
+// All returns an iterator over the values in ascending order. It takes no
+// locks and is weakly consistent: it never yields a value twice, but may or
+// may not reflect modifications made while it runs.
+func (c *ConcurrentList[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for curr := c.head.next.Load(); curr != nil; curr = curr.next.Load() {
+			if !curr.marked.Load() && !yield(curr.value) {
+				return
+			}
+		}
+	}
+}
+
+// locate returns the last node before v's position and the node at it, or
+// nil if v belongs at the end. Neither node is locked.
+func (c *ConcurrentList[T]) locate(v T) (pred, curr *cnode[T]) {
+	pred = &c.head
+	curr = pred.next.Load()
+	for curr != nil && cmp.Less(curr.value, v) {
+		pred, curr = curr, curr.next.Load()
+	}
+	return pred, curr
+}
+
+// lockPair locks pred and then curr, following list order so that
+// concurrent operations cannot deadlock.
+func lockPair[T cmp.Ordered](pred, curr *cnode[T]) {
+	pred.mu.Lock()
+	if curr != nil {
+		curr.mu.Lock()
+	}
+}
+
+func unlockPair[T cmp.Ordered](pred, curr *cnode[T]) {
+	if curr != nil {
+		curr.mu.Unlock()
+	}
+	pred.mu.Unlock()
+}
+
+// validPair reports whether pred and curr, both locked, are still live and
+// adjacent.
+func validPair[T cmp.Ordered](pred, curr *cnode[T]) bool {
+	return !pred.marked.Load() && pred.next.Load() == curr && (curr == nil || !curr.marked.Load())
+}
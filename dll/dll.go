@@ -4,7 +4,11 @@
 //   - Generic: works with any type `T` (Go 1.18+)
 //   - Typical list operations: PushFront, PushBack, InsertBefore, InsertAfter,
 //     Remove, Front, Back, Len, Clear, ToSlice, String, SortFunc
-//   - Iteration via node.Next / node.Prev
+//   - Iteration via node.Next / node.Prev or the fail-fast All, Backward and
+//     Nodes iterators
+//   - Persistence via encoding.BinaryMarshaler and gob
+//   - Optional node recycling through a sync.Pool (see WithNodePool) or slab
+//     allocation (see WithArena)
 //
 // Note: This implementation is NOT safe for concurrent use. Protect with a mutex
 // if you need concurrent access.
@@ -13,6 +17,8 @@ package dll
 
 import (
 	"fmt"
+	"iter"
+	"math/rand/v2"
 	"sort"
 	"strings"
 )
@@ -22,6 +28,7 @@ type Node[T any] struct {
 	Value T
 	prev  *Node[T]
 	next  *Node[T]
+	owner *listID[T] // identity of the owning list, nil once removed
 }
 
 // Prev returns the previous node (or nil).
@@ -30,15 +37,96 @@ func (n *Node[T]) Prev() *Node[T] { return n.prev }
 // Next returns the next node (or nil).
 func (n *Node[T]) Next() *Node[T] { return n.next }
 
+// listID identifies a list for node ownership checks. Nodes point at the
+// identity of their list rather than at the list itself so that Concat can
+// hand every node of one list to another in O(1): the donor's identity is
+// forwarded to the receiver's and the donor starts over with a fresh one.
+type listID[T any] struct {
+	list *List[T]
+	fwd  *listID[T]
+}
+
+// root follows forwarding links to the live identity, compressing the path
+// so later lookups are direct.
+func (id *listID[T]) root() *listID[T] {
+	r := id
+	for r.fwd != nil {
+		r = r.fwd
+	}
+	for id != r {
+		next := id.fwd
+		id.fwd = r
+		id = next
+	}
+	return r
+}
+
+// Detach removes n from the list it belongs to without discarding it: unlike
+// Remove, the node is never handed to a node pool, so it can later be put
+// back with List.ReattachAfter. It reports whether n was in a list.
+func (n *Node[T]) Detach() bool {
+	if n == nil || n.owner == nil {
+		return false
+	}
+	l := n.owner.root().list
+	if l == nil || !l.owns(n) {
+		return false
+	}
+	l.unlink(n)
+	n.prev, n.next = nil, nil
+	if l.cfg.onRemove != nil {
+		l.cfg.onRemove(n)
+	}
+	n.owner = nil
+	return true
+}
+
 // List is a generic doubly-linked list.
 type List[T any] struct {
 	head *Node[T]
 	tail *Node[T]
 	len  int
+	id   *listID[T]      // allocated lazily by ident
+	mod  uint64          // bumped on every structural change, see All
+	sidx *sortedIndex[T] // jump index built by FindSorted
+	cfg  config[T]       // settings applied by Options
+	mem  *nodeArena[T]   // slab allocator, see WithArena
 }
 
-// New returns an initialized empty list.
-func New[T any]() *List[T] { return &List[T]{} }
+// New returns an initialized empty list configured by opts.
+func New[T any](opts ...Option[T]) *List[T] {
+	l := &List[T]{}
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
+
+// FromSeq returns a list, configured by opts, holding the values yielded by
+// seq in order. Any iterator works, e.g. slices.Values, maps.Keys, or the All
+// method of another container in this module.
+func FromSeq[T any](seq iter.Seq[T], opts ...Option[T]) *List[T] {
+	l := New(opts...)
+	l.AppendSeq(seq)
+	return l
+}
+
+// ident returns the identity stamped on nodes owned by l.
+func (l *List[T]) ident() *listID[T] {
+	if l.id == nil {
+		l.id = &listID[T]{list: l}
+	}
+	return l.id
+}
+
+// owns reports whether n currently belongs to l.
+func (l *List[T]) owns(n *Node[T]) bool {
+	if n == nil || n.owner == nil {
+		return false
+	}
+	n.owner = n.owner.root()
+	return n.owner == l.id
+}
 
 // Len returns the number of elements in the list.
 func (l *List[T]) Len() int { return l.len }
@@ -51,7 +139,7 @@ func (l *List[T]) Back() *Node[T] { return l.tail }
 
 // PushFront inserts v at the front and returns the new node.
 func (l *List[T]) PushFront(v T) *Node[T] {
-	n := &Node[T]{Value: v}
+	n := l.newNode(v)
 	if l.head == nil {
 		l.head, l.tail = n, n
 	} else {
@@ -60,12 +148,14 @@ func (l *List[T]) PushFront(v T) *Node[T] {
 		l.head = n
 	}
 	l.len++
+	l.mod++
+	l.added(n, n, false)
 	return n
 }
 
 // PushBack inserts v at the back and returns the new node.
 func (l *List[T]) PushBack(v T) *Node[T] {
-	n := &Node[T]{Value: v}
+	n := l.newNode(v)
 	if l.tail == nil {
 		l.head, l.tail = n, n
 	} else {
@@ -74,19 +164,107 @@ func (l *List[T]) PushBack(v T) *Node[T] {
 		l.tail = n
 	}
 	l.len++
+	l.mod++
+	l.added(n, n, true)
 	return n
 }
 
+// PushBackSlice appends the values of s, in order, to the back of the list.
+// The nodes are allocated together and chained before being linked in, which
+// is considerably cheaper than calling PushBack for each value.
+func (l *List[T]) PushBackSlice(s []T) {
+	if len(s) == 0 {
+		return
+	}
+	head, tail := l.chain(s)
+	l.splice(l.tail, head, tail, len(s))
+	l.added(head, tail, true)
+}
+
+// PushFrontSlice inserts the values of s at the front of the list, keeping
+// their order, so that s[0] becomes the new front.
+func (l *List[T]) PushFrontSlice(s []T) {
+	if len(s) == 0 {
+		return
+	}
+	head, tail := l.chain(s)
+	l.splice(nil, head, tail, len(s))
+	l.added(head, tail, false)
+}
+
+// AppendSeq appends every value yielded by seq to the back of the list.
+func (l *List[T]) AppendSeq(seq iter.Seq[T]) {
+	var head, tail *Node[T]
+	n := 0
+	for v := range seq {
+		e := l.newNode(v)
+		e.prev = tail
+		if tail == nil {
+			head = e
+		} else {
+			tail.next = e
+		}
+		tail = e
+		n++
+	}
+	if n > 0 {
+		l.splice(l.tail, head, tail, n)
+		l.added(head, tail, true)
+	}
+}
+
+// chain builds a detached run of nodes owned by l holding the values of s.
+func (l *List[T]) chain(s []T) (head, tail *Node[T]) {
+	for _, v := range s {
+		e := l.newNode(v)
+		e.prev = tail
+		if tail == nil {
+			head = e
+		} else {
+			tail.next = e
+		}
+		tail = e
+	}
+	return head, tail
+}
+
+// splice links the detached run head..tail, holding n nodes already owned by
+// l, right after mark, or at the front when mark is nil.
+func (l *List[T]) splice(mark, head, tail *Node[T], n int) {
+	next := l.head
+	if mark != nil {
+		next = mark.next
+	}
+	head.prev = mark
+	tail.next = next
+	if mark == nil {
+		l.head = head
+	} else {
+		mark.next = head
+	}
+	if next == nil {
+		l.tail = tail
+	} else {
+		next.prev = tail
+	}
+	l.len += n
+	l.mod++
+}
+
 // InsertAfter inserts v after node n and returns the inserted node.
-// If n is nil, it behaves like PushBack.
+// If n is nil, it behaves like PushBack. If n does not belong to this list,
+// the list is left unchanged and InsertAfter returns nil.
 func (l *List[T]) InsertAfter(n *Node[T], v T) *Node[T] {
 	if n == nil {
 		return l.PushBack(v)
 	}
+	if !l.owns(n) {
+		return nil
+	}
 	if n == l.tail {
 		return l.PushBack(v)
 	}
-	newNode := &Node[T]{Value: v}
+	newNode := l.newNode(v)
 	next := n.next
 	newNode.prev = n
 	newNode.next = next
@@ -95,19 +273,25 @@ func (l *List[T]) InsertAfter(n *Node[T], v T) *Node[T] {
 		next.prev = newNode
 	}
 	l.len++
+	l.mod++
+	l.added(newNode, newNode, true)
 	return newNode
 }
 
 // InsertBefore inserts v before node n and returns the inserted node.
-// If n is nil, it behaves like PushFront.
+// If n is nil, it behaves like PushFront. If n does not belong to this list,
+// the list is left unchanged and InsertBefore returns nil.
 func (l *List[T]) InsertBefore(n *Node[T], v T) *Node[T] {
 	if n == nil {
 		return l.PushFront(v)
 	}
+	if !l.owns(n) {
+		return nil
+	}
 	if n == l.head {
 		return l.PushFront(v)
 	}
-	newNode := &Node[T]{Value: v}
+	newNode := l.newNode(v)
 	prev := n.prev
 	newNode.next = n
 	newNode.prev = prev
@@ -116,17 +300,71 @@ func (l *List[T]) InsertBefore(n *Node[T], v T) *Node[T] {
 		prev.next = newNode
 	}
 	l.len++
+	l.mod++
+	l.added(newNode, newNode, true)
 	return newNode
 }
 
-// Remove removes node n from the list and returns its value.
+// ReattachAfter links a node previously taken out with Node.Detach back into
+// l after mark, or at the back if mark is nil, and returns it. No allocation
+// takes place. It returns nil and leaves l unchanged if detached still belongs
+// to a list or mark is not in l.
+func (l *List[T]) ReattachAfter(mark, detached *Node[T]) *Node[T] {
+	if detached == nil || detached.owner != nil || (mark != nil && !l.owns(mark)) {
+		return nil
+	}
+	if mark == nil {
+		mark = l.tail
+	}
+	detached.owner = l.ident()
+	l.splice(mark, detached, detached, 1)
+	l.added(detached, detached, true)
+	return detached
+}
+
+// InsertOrdered inserts v at its sorted position in a list already sorted by
+// less and returns the new node. It scans from the back, so appending values
+// in mostly ascending order is cheap, and places v after any equal values.
+// If FindSorted has built a jump index that is still current, the position is
+// found through the index instead and the index is kept current.
+func (l *List[T]) InsertOrdered(v T, less func(a, b T) bool) *Node[T] {
+	if idx := l.sidx; idx != nil && idx.mod == l.mod && len(idx.marks) > 0 {
+		return l.insertIndexed(idx, v, less)
+	}
+	e := l.tail
+	for e != nil && less(v, e.Value) {
+		e = e.prev
+	}
+	if e == nil {
+		return l.PushFront(v)
+	}
+	return l.InsertAfter(e, v)
+}
+
+// Remove removes node n from the list and returns its value and true.
 // If n is nil or the node does not belong to this list, Remove does nothing and
-// returns the zero value of T.
-func (l *List[T]) Remove(n *Node[T]) (zero T) {
-	if n == nil || l.len == 0 {
-		return zero
+// returns the zero value of T and false.
+func (l *List[T]) Remove(n *Node[T]) (T, bool) {
+	if n == nil || !l.owns(n) {
+		var zero T
+		return zero, false
 	}
-	// Disconnect neighbors
+	v := n.Value
+	l.discard(n)
+	return v, true
+}
+
+// PopFront removes and returns the front value, or the zero value and false
+// if the list is empty.
+func (l *List[T]) PopFront() (T, bool) { return l.Remove(l.head) }
+
+// PopBack removes and returns the back value, or the zero value and false if
+// the list is empty.
+func (l *List[T]) PopBack() (T, bool) { return l.Remove(l.tail) }
+
+// unlink disconnects n from its neighbors and decrements the length, leaving
+// n's own links and ownership for the caller to reuse or clear.
+func (l *List[T]) unlink(n *Node[T]) {
 	if n.prev != nil {
 		n.prev.next = n.next
 	} else {
@@ -139,31 +377,119 @@ func (l *List[T]) Remove(n *Node[T]) (zero T) {
 		// n was tail
 		l.tail = n.prev
 	}
-	// Help GC
-	n.prev = nil
-	n.next = nil
 	l.len--
-	return n.Value
+	l.mod++
+}
+
+// SwapNodes exchanges the positions of nodes a and b by relinking them, so
+// each node keeps its Value and any references to it stay valid. It is a
+// no-op if a and b are the same node or either is not in this list.
+func (l *List[T]) SwapNodes(a, b *Node[T]) {
+	if a == nil || b == nil || a == b || !l.owns(a) || !l.owns(b) {
+		return
+	}
+	switch {
+	case a.next == b:
+		l.unlink(a)
+		l.splice(b, a, a, 1)
+	case b.next == a:
+		l.unlink(b)
+		l.splice(a, b, b, 1)
+	default:
+		aPrev, bPrev := a.prev, b.prev
+		l.unlink(a)
+		l.splice(bPrev, a, a, 1)
+		l.unlink(b)
+		l.splice(aPrev, b, b, 1)
+	}
 }
 
-// MoveToFront moves node n to the front. If n is already at front or nil, it's a no-op.
+// MoveToFront moves node n to the front in O(1). The node itself is relinked,
+// so references to it stay valid. If n is already at front, nil, or not in
+// this list, it's a no-op.
 func (l *List[T]) MoveToFront(n *Node[T]) {
-	if n == nil || n == l.head || l.len < 2 {
+	if n == nil || !l.owns(n) || n == l.head || l.len < 2 {
 		return
 	}
-	l.Remove(n)
-	val := n.Value
-	l.PushFront(val)
+	l.unlink(n)
+	l.splice(nil, n, n, 1)
 }
 
-// MoveToBack moves node n to the back. If n is already at back or nil, it's a no-op.
+// MoveToBack moves node n to the back in O(1). The node itself is relinked, so
+// references to it stay valid. If n is already at back, nil, or not in this
+// list, it's a no-op.
 func (l *List[T]) MoveToBack(n *Node[T]) {
-	if n == nil || n == l.tail || l.len < 2 {
+	if n == nil || !l.owns(n) || n == l.tail || l.len < 2 {
+		return
+	}
+	l.unlink(n)
+	l.splice(l.tail, n, n, 1)
+}
+
+// MoveBefore moves node n to just before mark in O(1). If n and mark are the
+// same node or either is not in this list, it's a no-op.
+func (l *List[T]) MoveBefore(n, mark *Node[T]) {
+	if n == nil || mark == nil || n == mark || !l.owns(n) || !l.owns(mark) {
+		return
+	}
+	l.unlink(n)
+	l.splice(mark.prev, n, n, 1)
+}
+
+// MoveAfter moves node n to just after mark in O(1). If n and mark are the
+// same node or either is not in this list, it's a no-op.
+func (l *List[T]) MoveAfter(n, mark *Node[T]) {
+	if n == nil || mark == nil || n == mark || !l.owns(n) || !l.owns(mark) {
 		return
 	}
-	l.Remove(n)
-	val := n.Value
-	l.PushBack(val)
+	l.unlink(n)
+	l.splice(mark, n, n, 1)
+}
+
+// CutRange detaches the nodes from first through last, inclusive, and returns
+// them as a new list. The nodes themselves move, so references to them stay
+// valid. The cost is one walk over the range to recount it and transfer
+// ownership. If either node is not in this list or last comes before first,
+// CutRange returns nil and leaves the list unchanged.
+func (l *List[T]) CutRange(first, last *Node[T]) *List[T] {
+	if first == nil || last == nil || !l.owns(first) || !l.owns(last) {
+		return nil
+	}
+	n := 1
+	for e := first; e != last; e = e.next {
+		if e.next == nil {
+			return nil
+		}
+		n++
+	}
+	out := l.derived()
+	for e := first; ; e = e.next {
+		e.owner = out.ident()
+		if e == last {
+			break
+		}
+	}
+
+	if first.prev != nil {
+		first.prev.next = last.next
+	} else {
+		l.head = last.next
+	}
+	if last.next != nil {
+		last.next.prev = first.prev
+	} else {
+		l.tail = first.prev
+	}
+	l.len -= n
+	l.mod++
+	first.prev, last.next = nil, nil
+	out.head, out.tail, out.len = first, last, n
+	if l.cfg.onRemove != nil {
+		for e := first; e != nil; e = e.next {
+			l.cfg.onRemove(e)
+		}
+	}
+	return out
 }
 
 // ToSlice returns a slice with the list elements in order.
@@ -178,22 +504,197 @@ func (l *List[T]) ToSlice() []T {
 // FromSlice replaces the list contents with elements from s.
 func (l *List[T]) FromSlice(s []T) {
 	l.Clear()
-	for _, v := range s {
-		l.PushBack(v)
-	}
+	l.PushBackSlice(s)
 }
 
-// Clear removes all elements from the list.
+// Clear removes all elements from the list. In arena mode without a remove
+// hook it runs in O(1), dropping the arena's slabs wholesale.
 func (l *List[T]) Clear() {
+	if l.cfg.onRemove != nil {
+		for l.head != nil {
+			l.discard(l.head)
+		}
+	}
+	if l.cfg.arenaBlock > 0 {
+		l.resetArena()
+		return
+	}
 	for e := l.head; e != nil; {
 		n := e.next
-		e.prev = nil
-		e.next = nil
+		l.release(e)
 		e = n
 	}
 	l.head = nil
 	l.tail = nil
 	l.len = 0
+	l.mod++
+}
+
+// Clone returns an independent copy of the list. Values are copied by
+// assignment; use CloneFunc when T holds references that must not be shared.
+func (l *List[T]) Clone() *List[T] {
+	return l.CloneFunc(func(v T) T { return v })
+}
+
+// CloneFunc returns an independent copy of the list whose values are produced
+// by applying copy to each value of l. The copy shares l's configuration,
+// such as its node pool.
+func (l *List[T]) CloneFunc(copy func(T) T) *List[T] {
+	out := l.derived()
+	for e := l.head; e != nil; e = e.next {
+		out.PushBack(copy(e.Value))
+	}
+	return out
+}
+
+// EqualFunc reports whether l and other have the same length and eq holds for
+// each pair of values at the same position.
+func (l *List[T]) EqualFunc(other *List[T], eq func(a, b T) bool) bool {
+	if l.len != other.len {
+		return false
+	}
+	for a, b := l.head, other.head; a != nil; a, b = a.next, b.next {
+		if !eq(a.Value, b.Value) {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal reports whether a and b hold equal values in the same order.
+func Equal[T comparable](a, b *List[T]) bool {
+	return a.EqualFunc(b, func(x, y T) bool { return x == y })
+}
+
+// At returns the node at index i (0 is the front), scanning from whichever
+// end is closer. It returns nil and false if i is out of range.
+func (l *List[T]) At(i int) (*Node[T], bool) {
+	if i < 0 || i >= l.len {
+		return nil, false
+	}
+	if i < l.len/2 {
+		e := l.head
+		for ; i > 0; i-- {
+			e = e.next
+		}
+		return e, true
+	}
+	e := l.tail
+	for j := l.len - 1; j > i; j-- {
+		e = e.prev
+	}
+	return e, true
+}
+
+// Middle returns the node at index Len()/2, the second of the two middle
+// nodes when the length is even, or nil for an empty list. It walks with a
+// slow and a fast pointer.
+func (l *List[T]) Middle() *Node[T] {
+	slow, fast := l.head, l.head
+	for fast != nil && fast.next != nil {
+		slow, fast = slow.next, fast.next.next
+	}
+	return slow
+}
+
+// NthFromEnd returns the node n positions before the back, so NthFromEnd(0)
+// is Back(). It returns nil if n is out of range. It walks a lead pointer n
+// nodes ahead and then advances both pointers together.
+func (l *List[T]) NthFromEnd(n int) *Node[T] {
+	if n < 0 {
+		return nil
+	}
+	lead := l.head
+	for i := 0; i < n; i++ {
+		if lead == nil {
+			return nil
+		}
+		lead = lead.next
+	}
+	if lead == nil {
+		return nil
+	}
+	trail := l.head
+	for lead.next != nil {
+		lead, trail = lead.next, trail.next
+	}
+	return trail
+}
+
+// IndexFunc returns the index of the first value satisfying pred, or -1.
+func (l *List[T]) IndexFunc(pred func(T) bool) int {
+	i := 0
+	for e := l.head; e != nil; e = e.next {
+		if pred(e.Value) {
+			return i
+		}
+		i++
+	}
+	return -1
+}
+
+// ForEach calls f for each value from front to back, stopping early if f
+// returns false. Like All, it panics if f structurally modifies the list.
+func (l *List[T]) ForEach(f func(T) bool) {
+	l.All()(f)
+}
+
+// ForEachReverse calls f for each value from back to front, stopping early if
+// f returns false. Like Backward, it panics if f structurally modifies the
+// list.
+func (l *List[T]) ForEachReverse(f func(T) bool) {
+	l.Backward()(f)
+}
+
+// All returns an iterator over the values from front to back.
+//
+// Iteration is fail-fast: if the list is structurally modified while the
+// iterator is running (including by the loop body), the next step panics
+// instead of walking links that may no longer be consistent. Assigning to a
+// node's Value is not a structural modification.
+func (l *List[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for e := range l.Nodes() {
+			if !yield(e.Value) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns a fail-fast iterator over the values from back to front.
+// See All for the modification rules.
+func (l *List[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		mod := l.mod
+		for e := l.tail; e != nil; e = e.prev {
+			if !yield(e.Value) {
+				return
+			}
+			l.checkMod(mod)
+		}
+	}
+}
+
+// Nodes returns a fail-fast iterator over the nodes from front to back. See
+// All for the modification rules.
+func (l *List[T]) Nodes() iter.Seq[*Node[T]] {
+	return func(yield func(*Node[T]) bool) {
+		mod := l.mod
+		for e := l.head; e != nil; e = e.next {
+			if !yield(e) {
+				return
+			}
+			l.checkMod(mod)
+		}
+	}
+}
+
+// checkMod panics if l has been structurally modified since mod was read.
+func (l *List[T]) checkMod(mod uint64) {
+	if l.mod != mod {
+		panic("dll: list modified during iteration")
+	}
 }
 
 // Find finds the first node that satisfies predicate f and returns it (or nil).
@@ -206,6 +707,92 @@ func (l *List[T]) Find(f func(T) bool) *Node[T] {
 	return nil
 }
 
+// RemoveIf removes every node whose value satisfies pred in a single pass and
+// returns how many were removed.
+func (l *List[T]) RemoveIf(pred func(T) bool) int {
+	removed := 0
+	for e := l.head; e != nil; {
+		next := e.next
+		if pred(e.Value) {
+			l.discard(e)
+			removed++
+		}
+		e = next
+	}
+	return removed
+}
+
+// FindLast finds the last node that satisfies predicate f, scanning from the
+// back, and returns it (or nil).
+func (l *List[T]) FindLast(f func(T) bool) *Node[T] {
+	for e := l.tail; e != nil; e = e.prev {
+		if f(e.Value) {
+			return e
+		}
+	}
+	return nil
+}
+
+// FindAll returns every node that satisfies predicate f, in list order.
+func (l *List[T]) FindAll(f func(T) bool) []*Node[T] {
+	var out []*Node[T]
+	for e := l.head; e != nil; e = e.next {
+		if f(e.Value) {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// Unique removes consecutive duplicates in place, keeping the first node of
+// each run of values equal under eq, and returns the number removed. On a
+// sorted list this leaves only distinct values.
+func (l *List[T]) Unique(eq func(a, b T) bool) int {
+	removed := 0
+	for e := l.head; e != nil && e.next != nil; {
+		if next := e.next; eq(e.Value, next.Value) {
+			l.discard(next)
+			removed++
+		} else {
+			e = next
+		}
+	}
+	return removed
+}
+
+// Count returns the number of values satisfying pred.
+func (l *List[T]) Count(pred func(T) bool) int {
+	n := 0
+	for e := l.head; e != nil; e = e.next {
+		if pred(e.Value) {
+			n++
+		}
+	}
+	return n
+}
+
+// CountValue returns the number of occurrences of v in l.
+func CountValue[T comparable](l *List[T], v T) int {
+	return l.Count(func(x T) bool { return x == v })
+}
+
+// Contains reports whether v is present in l.
+func Contains[T comparable](l *List[T], v T) bool {
+	return IndexOf(l, v) >= 0
+}
+
+// IndexOf returns the index of the first occurrence of v in l, or -1.
+func IndexOf[T comparable](l *List[T], v T) int {
+	return l.IndexFunc(func(x T) bool { return x == v })
+}
+
+// RemoveValue removes the first occurrence of v from l and reports whether
+// one was found.
+func RemoveValue[T comparable](l *List[T], v T) bool {
+	_, ok := l.Remove(l.Find(func(x T) bool { return x == v }))
+	return ok
+}
+
 // Reverse reverses the list in-place.
 func (l *List[T]) Reverse() {
 	if l.len < 2 {
@@ -217,6 +804,112 @@ func (l *List[T]) Reverse() {
 		cur = cur.prev // because we swapped
 	}
 	l.head, l.tail = l.tail, l.head
+	l.mod++
+}
+
+// Rotate rotates the list left by n positions, so that the element at index n
+// becomes the new front; a negative n rotates right. The ends are relinked in
+// place, walking at most half the list and allocating nothing.
+func (l *List[T]) Rotate(n int) {
+	if l.len < 2 {
+		return
+	}
+	k := n % l.len
+	if k < 0 {
+		k += l.len
+	}
+	if k == 0 {
+		return
+	}
+	var newHead *Node[T]
+	if k <= l.len/2 {
+		newHead = l.head
+		for i := 0; i < k; i++ {
+			newHead = newHead.next
+		}
+	} else {
+		newHead = l.tail
+		for i := l.len - 1; i > k; i-- {
+			newHead = newHead.prev
+		}
+	}
+	// Close the ring, then cut it just before newHead.
+	l.tail.next = l.head
+	l.head.prev = l.tail
+	l.head, l.tail = newHead, newHead.prev
+	l.head.prev = nil
+	l.tail.next = nil
+	l.mod++
+}
+
+// Validate walks the list and checks its structural invariants: prev/next
+// symmetry, head and tail terminating the chain, every node being owned by l,
+// and the node count matching Len. It returns a descriptive error for the
+// first violation found, or nil. It is intended for debugging and tests.
+func (l *List[T]) Validate() error {
+	if (l.head == nil) != (l.tail == nil) {
+		return fmt.Errorf("dll: head is %p but tail is %p", l.head, l.tail)
+	}
+	if l.head != nil && l.head.prev != nil {
+		return fmt.Errorf("dll: head has a previous node")
+	}
+	if l.tail != nil && l.tail.next != nil {
+		return fmt.Errorf("dll: tail has a next node")
+	}
+	count := 0
+	var last *Node[T]
+	for e := l.head; e != nil; e = e.next {
+		if count++; count > l.len {
+			return fmt.Errorf("dll: more than Len() = %d nodes reachable from head", l.len)
+		}
+		if !l.owns(e) {
+			return fmt.Errorf("dll: node %d is not owned by this list", count-1)
+		}
+		if e.prev != last {
+			return fmt.Errorf("dll: node %d has a broken prev link", count-1)
+		}
+		last = e
+	}
+	if last != l.tail {
+		return fmt.Errorf("dll: chain from head does not end at tail")
+	}
+	if count != l.len {
+		return fmt.Errorf("dll: counted %d nodes but Len() = %d", count, l.len)
+	}
+	return nil
+}
+
+// Shuffle randomly permutes the list in place using r, or the global source
+// if r is nil. Nodes are relinked, not copied, so references stay valid.
+func (l *List[T]) Shuffle(r *rand.Rand) {
+	if l.len < 2 {
+		return
+	}
+	nodes := make([]*Node[T], 0, l.len)
+	for e := l.head; e != nil; e = e.next {
+		nodes = append(nodes, e)
+	}
+	swap := func(i, j int) { nodes[i], nodes[j] = nodes[j], nodes[i] }
+	if r != nil {
+		r.Shuffle(len(nodes), swap)
+	} else {
+		rand.Shuffle(len(nodes), swap)
+	}
+	l.relink(nodes)
+}
+
+// relink rebuilds the chain of l from nodes, which must be exactly the
+// nodes of l in their new order.
+func (l *List[T]) relink(nodes []*Node[T]) {
+	for i, e := range nodes {
+		e.prev, e.next = nil, nil
+		if i > 0 {
+			e.prev = nodes[i-1]
+			nodes[i-1].next = e
+		}
+	}
+	l.head, l.tail = nodes[0], nodes[len(nodes)-1]
+	l.mod++
 }
 
 // String returns a string representation of the list values.
@@ -233,6 +926,40 @@ func (l *List[T]) String() string {
 	return sb.String()
 }
 
+// FormatFunc returns the list values rendered by f and joined by sep, in
+// brackets like String. A nil f formats values with %v.
+func (l *List[T]) FormatFunc(sep string, f func(T) string) string {
+	return l.FormatLimit(sep, -1, f)
+}
+
+// FormatLimit is like FormatFunc but renders at most max values; the rest
+// are summarized by count, e.g. "[1 2 3 … +997]". A negative max means no
+// limit.
+func (l *List[T]) FormatLimit(sep string, max int, f func(T) string) string {
+	if f == nil {
+		f = func(v T) string { return fmt.Sprintf("%v", v) }
+	}
+	var sb strings.Builder
+	sb.WriteString("[")
+	i := 0
+	for e := l.head; e != nil; e = e.next {
+		if i == max {
+			if i > 0 {
+				sb.WriteString(sep)
+			}
+			sb.WriteString(fmt.Sprintf("… +%d", l.len-i))
+			break
+		}
+		if i > 0 {
+			sb.WriteString(sep)
+		}
+		sb.WriteString(f(e.Value))
+		i++
+	}
+	sb.WriteString("]")
+	return sb.String()
+}
+
 // SortFunc sorts the list in place using the provided less function.
 // less(a, b) should return true if a < b.
 func (l *List[T]) SortFunc(less func(a, b T) bool) {
@@ -246,6 +973,205 @@ func (l *List[T]) SortFunc(less func(a, b T) bool) {
 	l.FromSlice(slice)
 }
 
+// MergeSorted merges other into l, assuming both are already sorted by less.
+// Nodes are relinked rather than copied, so the merge runs in O(n+m) without
+// allocating and leaves other empty. Equal elements from l precede those from
+// other.
+func (l *List[T]) MergeSorted(other *List[T], less func(a, b T) bool) {
+	if other == nil || other == l || other.len == 0 {
+		return
+	}
+	a, b := l.head, other.head
+	var head, tail *Node[T]
+	var moved []*Node[T] // nodes taken from other, kept only for hooks
+	track := l.hooksTransfer(other)
+	for a != nil || b != nil {
+		var n *Node[T]
+		if b == nil || (a != nil && !less(b.Value, a.Value)) {
+			n, a = a, a.next
+		} else {
+			n, b = b, b.next
+			n.owner = l.ident()
+			if track {
+				moved = append(moved, n)
+			}
+		}
+		n.prev = tail
+		n.next = nil
+		if tail == nil {
+			head = n
+		} else {
+			tail.next = n
+		}
+		tail = n
+	}
+	l.head, l.tail = head, tail
+	l.len += other.len
+	l.mod++
+	other.head, other.tail, other.len = nil, nil, 0
+	other.mod++
+	for _, n := range moved {
+		l.transferred(other, n)
+	}
+	l.evict(true)
+}
+
+// PushBackList inserts a copy of other's values at the back of l, leaving
+// other unchanged. l and other may be the same list.
+func (l *List[T]) PushBackList(other *List[T]) {
+	// Copy first: with a length cap, pushing onto l may evict and recycle
+	// the very nodes being read when other == l.
+	l.PushBackSlice(other.ToSlice())
+}
+
+// PushFrontList inserts a copy of other's values at the front of l, keeping
+// their order and leaving other unchanged. l and other may be the same list.
+func (l *List[T]) PushFrontList(other *List[T]) {
+	l.PushFrontSlice(other.ToSlice())
+}
+
+// Concat moves all nodes of other to the back of l in O(1) by splicing
+// other's chain onto l's tail, and leaves other empty. The moved nodes keep
+// their identity and now belong to l. If either list has hooks (see
+// WithHooks), the moved nodes are walked once to fire them.
+func (l *List[T]) Concat(other *List[T]) {
+	if other == nil || other == l || other.len == 0 {
+		return
+	}
+	first := other.head
+	l.splice(l.tail, other.head, other.tail, other.len)
+	other.id.fwd = l.ident()
+	other.id = nil
+	other.head, other.tail, other.len = nil, nil, 0
+	other.mod++
+	if l.hooksTransfer(other) {
+		for e := first; e != nil; e = e.next {
+			l.transferred(other, e)
+		}
+	}
+	l.evict(true)
+}
+
+// Interleave relinks the nodes of other into l so that values alternate,
+// starting with l's front: l0, o0, l1, o1, ... Whatever remains of the longer
+// list follows in order. other is left empty.
+func (l *List[T]) Interleave(other *List[T]) {
+	if other == nil || other == l || other.len == 0 {
+		return
+	}
+	a, b := l.head, other.head
+	var head, tail *Node[T]
+	var moved []*Node[T] // nodes taken from other, kept only for hooks
+	track := l.hooksTransfer(other)
+	fromA := true
+	for a != nil || b != nil {
+		var n *Node[T]
+		if b == nil || (a != nil && fromA) {
+			n, a = a, a.next
+		} else {
+			n, b = b, b.next
+			n.owner = l.ident()
+			if track {
+				moved = append(moved, n)
+			}
+		}
+		fromA = !fromA
+		n.prev = tail
+		n.next = nil
+		if tail == nil {
+			head = n
+		} else {
+			tail.next = n
+		}
+		tail = n
+	}
+	l.head, l.tail = head, tail
+	l.len += other.len
+	l.mod++
+	other.head, other.tail, other.len = nil, nil, 0
+	other.mod++
+	for _, n := range moved {
+		l.transferred(other, n)
+	}
+	l.evict(true)
+}
+
+// ZipFunc returns a new list holding f applied to pairs of values at the same
+// position in a and b. It stops at the end of the shorter list.
+func ZipFunc[A, B, R any](a *List[A], b *List[B], f func(A, B) R) *List[R] {
+	out := New[R]()
+	for x, y := a.head, b.head; x != nil && y != nil; x, y = x.next, y.next {
+		out.PushBack(f(x.Value, y.Value))
+	}
+	return out
+}
+
+// Filter returns a new list containing the values for which keep returns
+// true, in order. The receiver is not modified.
+func (l *List[T]) Filter(keep func(T) bool) *List[T] {
+	out := New[T]()
+	for e := l.head; e != nil; e = e.next {
+		if keep(e.Value) {
+			out.PushBack(e.Value)
+		}
+	}
+	return out
+}
+
+// Partition splits l in a single pass into a list of the values satisfying
+// pred and a list of the rest, each keeping the original order. Nodes are
+// relinked rather than copied, and l is left empty.
+func (l *List[T]) Partition(pred func(T) bool) (matched, rest *List[T]) {
+	matched, rest = l.derived(), l.derived()
+	for e := l.head; e != nil; {
+		next := e.next
+		dst := rest
+		if pred(e.Value) {
+			dst = matched
+		}
+		e.owner = dst.ident()
+		e.prev, e.next = dst.tail, nil
+		if dst.tail == nil {
+			dst.head = e
+		} else {
+			dst.tail.next = e
+		}
+		dst.tail = e
+		dst.len++
+		e = next
+	}
+	l.head, l.tail, l.len = nil, nil, 0
+	l.mod++
+	if l.cfg.onRemove != nil {
+		for _, dst := range []*List[T]{matched, rest} {
+			for e := dst.head; e != nil; e = e.next {
+				l.cfg.onRemove(e)
+			}
+		}
+	}
+	return matched, rest
+}
+
+// MapTo returns a new list holding f applied to each value of l, in order.
+// It is a function rather than a method because methods cannot introduce
+// type parameters.
+func MapTo[T, U any](l *List[T], f func(T) U) *List[U] {
+	out := New[U]()
+	for e := l.head; e != nil; e = e.next {
+		out.PushBack(f(e.Value))
+	}
+	return out
+}
+
+// Reduce folds the values of l from front to back, starting from init.
+func Reduce[T, A any](l *List[T], init A, f func(acc A, v T) A) A {
+	acc := init
+	for e := l.head; e != nil; e = e.next {
+		acc = f(acc, e.Value)
+	}
+	return acc
+}
+
 // Example usage (not executed):
 //
 //  l := dll.New[int]()
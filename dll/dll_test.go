@@ -1,8 +1,15 @@
 package dll
 
 import (
+	"bytes"
+	"encoding/gob"
 	"fmt"
+	"math/rand/v2"
+	"runtime"
+	"slices"
+	"sync"
 	"testing"
+	"weak"
 )
 
 func TestPushAndToSlice(t *testing.T) {
@@ -46,3 +53,1022 @@ func TestStringer(t *testing.T) {
 		t.Errorf("expected [1 2 3], got %v", got)
 	}
 }
+
+func TestBinaryRoundTrip(t *testing.T) {
+	l := New[string]()
+	l.FromSlice([]string{"a", "", "c"})
+	data, err := l.MarshalBinary()
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+
+	got := New[string]()
+	got.PushBack("stale")
+	if err := got.UnmarshalBinary(data); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if fmt.Sprint(got.ToSlice()) != fmt.Sprint(l.ToSlice()) || got.Len() != 3 {
+		t.Errorf("expected %v, got %v", l, got)
+	}
+
+	if err := got.UnmarshalBinary([]byte("XYZ")); err == nil {
+		t.Errorf("expected error for bad header")
+	}
+	data[3] = binaryVersion + 1
+	if err := got.UnmarshalBinary(data); err == nil {
+		t.Errorf("expected error for unknown version")
+	}
+}
+
+func TestGob(t *testing.T) {
+	type snapshot struct {
+		Items *List[int]
+	}
+	in := snapshot{Items: New[int]()}
+	in.Items.FromSlice([]int{1, 2, 3})
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(in); err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	var out snapshot
+	if err := gob.NewDecoder(&buf).Decode(&out); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if got := out.Items.String(); got != "[1 2 3]" {
+		t.Errorf("expected [1 2 3], got %v", got)
+	}
+}
+
+func TestForeignNode(t *testing.T) {
+	a, b := New[int](), New[int]()
+	a.FromSlice([]int{1, 2})
+	nb := b.PushBack(3)
+
+	if _, ok := a.Remove(nb); ok {
+		t.Errorf("expected Remove of foreign node to fail")
+	}
+	if n := a.InsertAfter(nb, 9); n != nil {
+		t.Errorf("expected InsertAfter on foreign node to return nil")
+	}
+	if n := a.InsertBefore(nb, 9); n != nil {
+		t.Errorf("expected InsertBefore on foreign node to return nil")
+	}
+	if a.String() != "[1 2]" || b.String() != "[3]" {
+		t.Errorf("lists modified: %v %v", a, b)
+	}
+
+	if v, ok := b.Remove(nb); !ok || v != 3 {
+		t.Errorf("expected (3, true), got (%v, %v)", v, ok)
+	}
+	if _, ok := b.Remove(nb); ok {
+		t.Errorf("expected second Remove to fail")
+	}
+}
+
+func TestMergeSorted(t *testing.T) {
+	a, b := New[int](), New[int]()
+	a.FromSlice([]int{1, 4, 5, 9})
+	b.FromSlice([]int{2, 3, 5, 10, 11})
+	nb := b.Front()
+
+	a.MergeSorted(b, func(x, y int) bool { return x < y })
+
+	expected := []int{1, 2, 3, 4, 5, 5, 9, 10, 11}
+	if got := a.ToSlice(); fmt.Sprint(got) != fmt.Sprint(expected) || a.Len() != len(expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+	if a.Back().Value != 11 || b.Len() != 0 || b.Front() != nil {
+		t.Errorf("unexpected state: a=%v b=%v", a, b)
+	}
+	if _, ok := a.Remove(nb); !ok {
+		t.Errorf("expected merged node to belong to receiver")
+	}
+}
+
+func TestMapFilterReduce(t *testing.T) {
+	l := New[int]()
+	l.FromSlice([]int{1, 2, 3, 4})
+
+	evens := l.Filter(func(v int) bool { return v%2 == 0 })
+	if got := evens.String(); got != "[2 4]" {
+		t.Errorf("expected [2 4], got %v", got)
+	}
+	strs := MapTo(l, func(v int) string { return fmt.Sprint(v * 10) })
+	if got := strs.String(); got != "[10 20 30 40]" {
+		t.Errorf("expected [10 20 30 40], got %v", got)
+	}
+	sum := Reduce(l, 0, func(acc, v int) int { return acc + v })
+	if sum != 10 {
+		t.Errorf("expected 10, got %v", sum)
+	}
+	if l.Len() != 4 {
+		t.Errorf("expected receiver untouched, got %v", l)
+	}
+}
+
+func TestBatchPush(t *testing.T) {
+	l := New[int]()
+	l.PushBackSlice([]int{3, 4})
+	l.PushFrontSlice([]int{1, 2})
+	l.PushBackSlice(nil)
+	l.AppendSeq(slices.Values([]int{5, 6}))
+
+	expected := []int{1, 2, 3, 4, 5, 6}
+	if got := l.ToSlice(); fmt.Sprint(got) != fmt.Sprint(expected) || l.Len() != 6 {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+	if l.Front().Prev() != nil || l.Back().Value != 6 || l.Back().Prev().Value != 5 {
+		t.Errorf("broken links around the ends")
+	}
+}
+
+func TestBatchPushReleasesRemoved(t *testing.T) {
+	type payload struct{ _ [64]byte }
+	vals := make([]*payload, 100)
+	refs := make([]weak.Pointer[payload], len(vals))
+	for i := range vals {
+		vals[i] = new(payload)
+		refs[i] = weak.Make(vals[i])
+	}
+	l := New[*payload]()
+	l.PushBackSlice(vals)
+	vals = nil
+	for l.Len() > 1 {
+		l.Remove(l.Front())
+	}
+	runtime.GC()
+	for i, r := range refs[:len(refs)-1] {
+		if r.Value() != nil {
+			t.Fatalf("removed value %d still reachable", i)
+		}
+	}
+	runtime.KeepAlive(l)
+}
+
+func TestPooled(t *testing.T) {
+	pool := NewNodePool[int]()
+	l := New(WithNodePool(pool))
+	for i := 0; i < 100; i++ {
+		l.PushBack(i)
+		if i%3 == 0 {
+			l.Remove(l.Front())
+		}
+	}
+	l.PushBackSlice([]int{-1, -2})
+	l.MoveToFront(l.Back())
+	if l.Len() != 68 || l.Front().Value != -2 || l.Back().Value != -1 {
+		t.Errorf("unexpected pooled list state: len=%v %v", l.Len(), l)
+	}
+	l.Clear()
+
+	other := NewPooled[string]()
+	other.PushBack("x")
+	if v, ok := other.Remove(other.Front()); !ok || v != "x" {
+		t.Errorf("expected (x, true), got (%v, %v)", v, ok)
+	}
+}
+
+func TestRotate(t *testing.T) {
+	tests := []struct {
+		n        int
+		expected []int
+	}{
+		{0, []int{1, 2, 3, 4, 5}},
+		{1, []int{2, 3, 4, 5, 1}},
+		{4, []int{5, 1, 2, 3, 4}},
+		{-1, []int{5, 1, 2, 3, 4}},
+		{7, []int{3, 4, 5, 1, 2}},
+	}
+	for _, tt := range tests {
+		l := New[int]()
+		l.FromSlice([]int{1, 2, 3, 4, 5})
+		l.Rotate(tt.n)
+		if got := l.ToSlice(); fmt.Sprint(got) != fmt.Sprint(tt.expected) {
+			t.Errorf("Rotate(%d): expected %v, got %v", tt.n, tt.expected, got)
+		}
+		if l.Front().Prev() != nil || l.Back().Next() != nil {
+			t.Errorf("Rotate(%d): ends not terminated", tt.n)
+		}
+	}
+}
+
+func TestSwapNodes(t *testing.T) {
+	l := New[int]()
+	l.FromSlice([]int{1, 2, 3, 4, 5})
+	first, second, last := l.Front(), l.Front().Next(), l.Back()
+
+	l.SwapNodes(first, last) // distant
+	if got := l.String(); got != "[5 2 3 4 1]" {
+		t.Errorf("expected [5 2 3 4 1], got %v", got)
+	}
+	l.SwapNodes(second, last) // adjacent, b before a
+	if got := l.String(); got != "[2 5 3 4 1]" {
+		t.Errorf("expected [2 5 3 4 1], got %v", got)
+	}
+	l.SwapNodes(second, last) // adjacent, a before b
+	if got := l.String(); got != "[5 2 3 4 1]" {
+		t.Errorf("expected [5 2 3 4 1], got %v", got)
+	}
+	if l.Front() != last || l.Back() != first || first.Value != 1 || l.Len() != 5 {
+		t.Errorf("node identity not preserved")
+	}
+}
+
+func TestMoveBeforeAfter(t *testing.T) {
+	l := New[int]()
+	l.FromSlice([]int{1, 2, 3, 4})
+	one, four := l.Front(), l.Back()
+
+	l.MoveAfter(one, four)
+	if got := l.String(); got != "[2 3 4 1]" || l.Back() != one {
+		t.Errorf("expected [2 3 4 1], got %v", got)
+	}
+	l.MoveBefore(one, l.Front())
+	if got := l.String(); got != "[1 2 3 4]" || l.Front() != one {
+		t.Errorf("expected [1 2 3 4], got %v", got)
+	}
+	l.MoveBefore(four, one.Next())
+	if got := l.String(); got != "[1 4 2 3]" || l.Len() != 4 {
+		t.Errorf("expected [1 4 2 3], got %v", got)
+	}
+	l.MoveAfter(four, four)
+	if got := l.String(); got != "[1 4 2 3]" {
+		t.Errorf("expected no-op, got %v", got)
+	}
+}
+
+func TestRemoveIf(t *testing.T) {
+	l := New[int]()
+	l.FromSlice([]int{2, 1, 4, 3, 6})
+	if n := l.RemoveIf(func(v int) bool { return v%2 == 0 }); n != 3 {
+		t.Errorf("expected 3 removed, got %v", n)
+	}
+	if got := l.String(); got != "[1 3]" || l.Len() != 2 || l.Back().Value != 3 {
+		t.Errorf("expected [1 3], got %v", got)
+	}
+}
+
+func TestClone(t *testing.T) {
+	l := New[[]int]()
+	l.PushBack([]int{1})
+	l.PushBack([]int{2})
+
+	shallow := l.Clone()
+	deep := l.CloneFunc(func(v []int) []int { return slices.Clone(v) })
+	l.Front().Value[0] = 9
+	l.PushBack([]int{3})
+
+	if shallow.Len() != 2 || deep.Len() != 2 {
+		t.Errorf("clones should be independent of later pushes")
+	}
+	if shallow.Front().Value[0] != 9 {
+		t.Errorf("expected shallow clone to share backing arrays")
+	}
+	if got := deep.String(); got != "[[1] [2]]" {
+		t.Errorf("expected [[1] [2]], got %v", got)
+	}
+}
+
+func TestEqual(t *testing.T) {
+	a, b := New[int](), New[int]()
+	a.FromSlice([]int{1, 2, 3})
+	b.FromSlice([]int{1, 2, 3})
+	if !Equal(a, b) {
+		t.Errorf("expected %v == %v", a, b)
+	}
+	b.PushBack(4)
+	if Equal(a, b) {
+		t.Errorf("expected lists of different length to differ")
+	}
+	b.FromSlice([]int{-1, -2, -3})
+	abs := func(x, y int) bool { return x == -y }
+	if !a.EqualFunc(b, abs) || Equal(a, b) {
+		t.Errorf("unexpected EqualFunc result for %v and %v", a, b)
+	}
+}
+
+func TestFindLastAndAll(t *testing.T) {
+	l := New[int]()
+	l.FromSlice([]int{1, 2, 3, 4, 5})
+	even := func(v int) bool { return v%2 == 0 }
+
+	if n := l.FindLast(even); n == nil || n.Value != 4 {
+		t.Errorf("expected last even 4, got %v", n)
+	}
+	all := l.FindAll(even)
+	if len(all) != 2 || all[0].Value != 2 || all[1].Value != 4 {
+		t.Errorf("expected nodes [2 4], got %v", all)
+	}
+	if l.FindLast(func(v int) bool { return v > 5 }) != nil {
+		t.Errorf("expected nil for no match")
+	}
+}
+
+func TestAtAndIndexFunc(t *testing.T) {
+	l := New[int]()
+	l.FromSlice([]int{10, 20, 30, 40, 50})
+	for i, v := range []int{10, 20, 30, 40, 50} {
+		if n, ok := l.At(i); !ok || n.Value != v {
+			t.Errorf("At(%d): expected %v, got %v", i, v, n)
+		}
+	}
+	if _, ok := l.At(5); ok {
+		t.Errorf("expected At(5) to fail")
+	}
+	if _, ok := l.At(-1); ok {
+		t.Errorf("expected At(-1) to fail")
+	}
+	if i := l.IndexFunc(func(v int) bool { return v > 25 }); i != 2 {
+		t.Errorf("expected index 2, got %v", i)
+	}
+	if i := l.IndexFunc(func(v int) bool { return v > 50 }); i != -1 {
+		t.Errorf("expected index -1, got %v", i)
+	}
+}
+
+func TestUnique(t *testing.T) {
+	l := New[int]()
+	l.FromSlice([]int{1, 1, 2, 3, 3, 3, 1})
+	if n := l.Unique(func(a, b int) bool { return a == b }); n != 3 {
+		t.Errorf("expected 3 removed, got %v", n)
+	}
+	if got := l.String(); got != "[1 2 3 1]" || l.Len() != 4 || l.Back().Prev().Value != 3 {
+		t.Errorf("expected [1 2 3 1], got %v", got)
+	}
+}
+
+func TestCutRange(t *testing.T) {
+	l := New[int]()
+	l.FromSlice([]int{1, 2, 3, 4, 5})
+	second, _ := l.At(1)
+	fourth, _ := l.At(3)
+
+	if l.CutRange(fourth, second) != nil {
+		t.Errorf("expected nil for reversed range")
+	}
+	cut := l.CutRange(second, fourth)
+	if got := cut.String(); got != "[2 3 4]" || cut.Len() != 3 {
+		t.Errorf("expected [2 3 4], got %v", got)
+	}
+	if got := l.String(); got != "[1 5]" || l.Len() != 2 || l.Back().Prev() != l.Front() {
+		t.Errorf("expected [1 5], got %v", got)
+	}
+	if _, ok := cut.Remove(second); !ok {
+		t.Errorf("expected cut node to belong to the new list")
+	}
+
+	whole := l.CutRange(l.Front(), l.Back())
+	if l.Len() != 0 || l.Front() != nil || l.Back() != nil || whole.String() != "[1 5]" {
+		t.Errorf("expected whole list to move, got %v and %v", l, whole)
+	}
+}
+
+func TestMoveToFrontKeepsNode(t *testing.T) {
+	l := New[int]()
+	l.FromSlice([]int{1, 2, 3})
+	mid := l.Front().Next()
+
+	l.MoveToFront(mid)
+	if l.Front() != mid || l.String() != "[2 1 3]" {
+		t.Errorf("expected node moved to front, got %v", l)
+	}
+	l.MoveToBack(mid)
+	if l.Back() != mid || l.String() != "[1 3 2]" || l.Len() != 3 {
+		t.Errorf("expected node moved to back, got %v", l)
+	}
+	if _, ok := l.Remove(mid); !ok {
+		t.Errorf("expected moved node to remain removable")
+	}
+}
+
+func TestInsertOrdered(t *testing.T) {
+	l := New[int]()
+	less := func(a, b int) bool { return a < b }
+	for _, v := range []int{5, 1, 4, 1, 9, 0} {
+		l.InsertOrdered(v, less)
+	}
+	expected := []int{0, 1, 1, 4, 5, 9}
+	if got := l.ToSlice(); fmt.Sprint(got) != fmt.Sprint(expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestForEach(t *testing.T) {
+	l := New[int]()
+	l.FromSlice([]int{1, 2, 3, 4})
+
+	var seen []int
+	l.ForEach(func(v int) bool {
+		seen = append(seen, v)
+		return v < 2
+	})
+	if fmt.Sprint(seen) != "[1 2]" {
+		t.Errorf("expected [1 2], got %v", seen)
+	}
+
+	seen = nil
+	l.ForEachReverse(func(v int) bool {
+		seen = append(seen, v)
+		return true
+	})
+	if fmt.Sprint(seen) != "[4 3 2 1]" {
+		t.Errorf("expected [4 3 2 1], got %v", seen)
+	}
+}
+
+func TestComparableHelpers(t *testing.T) {
+	l := New[string]()
+	l.FromSlice([]string{"a", "b", "c", "b"})
+
+	if !Contains(l, "c") || Contains(l, "z") {
+		t.Errorf("unexpected Contains result")
+	}
+	if i := IndexOf(l, "b"); i != 1 {
+		t.Errorf("expected index 1, got %v", i)
+	}
+	if !RemoveValue(l, "b") || RemoveValue(l, "z") {
+		t.Errorf("unexpected RemoveValue result")
+	}
+	if got := l.String(); got != "[a c b]" {
+		t.Errorf("expected [a c b], got %v", got)
+	}
+}
+
+func TestInterleaveAndZip(t *testing.T) {
+	a, b := New[int](), New[int]()
+	a.FromSlice([]int{1, 3})
+	b.FromSlice([]int{2, 4, 6, 8})
+
+	zipped := ZipFunc(a, b, func(x, y int) string { return fmt.Sprintf("%d-%d", x, y) })
+	if got := zipped.String(); got != "[1-2 3-4]" {
+		t.Errorf("expected [1-2 3-4], got %v", got)
+	}
+
+	a.Interleave(b)
+	expected := []int{1, 2, 3, 4, 6, 8}
+	if got := a.ToSlice(); fmt.Sprint(got) != fmt.Sprint(expected) || a.Len() != 6 {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+	if b.Len() != 0 || b.Front() != nil || a.Back().Value != 8 {
+		t.Errorf("unexpected state: a=%v b=%v", a, b)
+	}
+}
+
+func TestPartition(t *testing.T) {
+	l := New[int]()
+	l.FromSlice([]int{1, 2, 3, 4, 5})
+	two := l.Front().Next()
+
+	evens, odds := l.Partition(func(v int) bool { return v%2 == 0 })
+	if evens.String() != "[2 4]" || odds.String() != "[1 3 5]" {
+		t.Errorf("expected [2 4] and [1 3 5], got %v and %v", evens, odds)
+	}
+	if evens.Len() != 2 || odds.Len() != 3 || l.Len() != 0 || l.Front() != nil {
+		t.Errorf("unexpected lengths")
+	}
+	if _, ok := evens.Remove(two); !ok {
+		t.Errorf("expected node to move to the matched list")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	l := New[int]()
+	if err := l.Validate(); err != nil {
+		t.Errorf("empty list: %v", err)
+	}
+	l.FromSlice([]int{1, 2, 3})
+	l.MoveToFront(l.Back())
+	l.SwapNodes(l.Front(), l.Back())
+	if err := l.Validate(); err != nil {
+		t.Errorf("valid list: %v", err)
+	}
+
+	l.len++
+	if err := l.Validate(); err == nil {
+		t.Errorf("expected length mismatch to be reported")
+	}
+	l.len--
+	l.Front().Next().prev = nil
+	if err := l.Validate(); err == nil {
+		t.Errorf("expected broken prev link to be reported")
+	}
+}
+
+func TestShuffle(t *testing.T) {
+	l := New[int]()
+	l.FromSlice([]int{1, 2, 3, 4, 5, 6, 7, 8})
+	l.Shuffle(rand.New(rand.NewPCG(1, 2)))
+
+	got := l.ToSlice()
+	slices.Sort(got)
+	if fmt.Sprint(got) != "[1 2 3 4 5 6 7 8]" {
+		t.Errorf("shuffle lost elements: %v", l)
+	}
+	if err := l.Validate(); err != nil {
+		t.Errorf("shuffled list invalid: %v", err)
+	}
+}
+
+func TestConcat(t *testing.T) {
+	a, b, c := New[int](), New[int](), New[int]()
+	a.FromSlice([]int{1, 2})
+	b.FromSlice([]int{3, 4})
+	c.FromSlice([]int{5})
+	three := b.Front()
+
+	a.Concat(b)
+	if got := a.String(); got != "[1 2 3 4]" || b.Len() != 0 || b.Front() != nil {
+		t.Errorf("expected [1 2 3 4] and empty donor, got %v and %v", a, b)
+	}
+	b.PushBack(9)
+	if _, ok := a.Remove(b.Front()); ok {
+		t.Errorf("donor's new nodes must not belong to the receiver")
+	}
+
+	c.Concat(a)
+	if _, ok := a.Remove(three); ok {
+		t.Errorf("node should have followed the second concat")
+	}
+	if v, ok := c.Remove(three); !ok || v != 3 {
+		t.Errorf("expected (3, true), got (%v, %v)", v, ok)
+	}
+	if err := c.Validate(); err != nil || c.String() != "[5 1 2 4]" {
+		t.Errorf("expected valid [5 1 2 4], got %v (%v)", c, err)
+	}
+}
+
+func TestPushListsAndCompat(t *testing.T) {
+	l := New[int]()
+	l.FromSlice([]int{1, 2})
+	l.PushBackList(l)
+	l.PushFrontList(l)
+	if got := l.String(); got != "[1 2 1 2 1 2 1 2]" {
+		t.Errorf("expected [1 2 1 2 1 2 1 2], got %v", got)
+	}
+
+	for _, opts := range [][]Option[int]{
+		{WithMaxLen[int](3, nil)},
+		{WithMaxLen[int](3, nil), WithNodePool(NewNodePool[int]())},
+		{WithMaxLen[int](3, nil), WithArena[int](2)},
+	} {
+		bl := New(opts...)
+		bl.PushBackSlice([]int{1, 2, 3})
+		bl.PushBackList(bl)
+		if err := bl.Validate(); err != nil || bl.String() != "[1 2 3]" {
+			t.Errorf("bounded PushBackList: expected valid [1 2 3], got %v (%v)", bl, err)
+		}
+		bl.PushBack(4)
+		bl.PushFrontList(bl)
+		if err := bl.Validate(); err != nil || bl.String() != "[2 3 4]" {
+			t.Errorf("bounded PushFrontList: expected valid [2 3 4], got %v (%v)", bl, err)
+		}
+	}
+
+	c := NewCompat[string]().Init()
+	b := c.PushBack("b")
+	c.InsertBefore("a", b)
+	c.InsertAfter("c", b)
+	if c.InsertAfter("x", nil) != nil {
+		t.Errorf("expected nil for nil mark")
+	}
+	if v := c.Remove(b); v != "b" {
+		t.Errorf("expected b, got %v", v)
+	}
+	c.PushBackList(c)
+	if got := c.String(); got != "[a c a c]" || c.Len() != 4 {
+		t.Errorf("expected [a c a c], got %v", got)
+	}
+}
+
+func TestPersistent(t *testing.T) {
+	var empty Persistent[int]
+	a := empty.PushFront(1)
+	b := a.PushFront(2)
+	c := a.PushFront(3)
+
+	if b.String() != "[2 1]" || c.String() != "[3 1]" || a.String() != "[1]" {
+		t.Errorf("snapshots changed: a=%v b=%v c=%v", a, b, c)
+	}
+	v, rest, ok := b.PopFront()
+	if !ok || v != 2 || rest.String() != "[1]" || b.Len() != 2 {
+		t.Errorf("unexpected PopFront result %v %v %v", v, rest, ok)
+	}
+	if r := c.PushFront(4).Reverse(); r.String() != "[1 3 4]" || r.Len() != 3 {
+		t.Errorf("expected [1 3 4], got %v", r)
+	}
+	if _, _, ok := empty.PopFront(); ok {
+		t.Errorf("expected PopFront on empty list to fail")
+	}
+}
+
+func TestIteratorsFailFast(t *testing.T) {
+	l := New[int]()
+	l.FromSlice([]int{1, 2, 3})
+	if got := slices.Collect(l.All()); fmt.Sprint(got) != "[1 2 3]" {
+		t.Errorf("expected [1 2 3], got %v", got)
+	}
+	if got := slices.Collect(l.Backward()); fmt.Sprint(got) != "[3 2 1]" {
+		t.Errorf("expected [3 2 1], got %v", got)
+	}
+	for n := range l.Nodes() {
+		n.Value *= 10 // value updates are allowed
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected panic on modification during iteration")
+		}
+		if got := l.String(); got != "[20 30]" {
+			t.Errorf("expected [20 30], got %v", got)
+		}
+	}()
+	for n := range l.Nodes() {
+		l.Remove(n)
+	}
+}
+
+func TestCount(t *testing.T) {
+	l := New[int]()
+	l.FromSlice([]int{1, 2, 2, 3, 2})
+	if n := l.Count(func(v int) bool { return v > 1 }); n != 4 {
+		t.Errorf("expected 4, got %v", n)
+	}
+	if n := CountValue(l, 2); n != 3 {
+		t.Errorf("expected 3, got %v", n)
+	}
+}
+
+func TestFormat(t *testing.T) {
+	l := New[int]()
+	l.FromSlice([]int{1, 2, 3, 4, 5})
+	if got := l.FormatFunc(", ", nil); got != "[1, 2, 3, 4, 5]" {
+		t.Errorf("expected [1, 2, 3, 4, 5], got %v", got)
+	}
+	hex := func(v int) string { return fmt.Sprintf("%#x", v) }
+	if got := l.FormatLimit(" ", 3, hex); got != "[0x1 0x2 0x3 … +2]" {
+		t.Errorf("expected [0x1 0x2 0x3 … +2], got %v", got)
+	}
+	if got := l.FormatLimit(" ", 5, nil); got != l.String() {
+		t.Errorf("expected %v, got %v", l, got)
+	}
+	if got := l.FormatLimit(" ", 0, nil); got != "[… +5]" {
+		t.Errorf("expected [… +5], got %v", got)
+	}
+}
+
+func TestIndexedList(t *testing.T) {
+	il := NewIndexed[string, int]()
+	il.PushFront("a", 1)
+	il.PushFront("b", 2)
+	il.PushFront("c", 3) // c b a
+	if il.PushFront("a", 10) {
+		t.Errorf("expected existing key to be updated, not added")
+	}
+	if v, ok := il.Get("a"); !ok || v != 10 {
+		t.Errorf("expected (10, true), got (%v, %v)", v, ok)
+	}
+	il.MoveToFront("b") // b a c
+
+	if k, v, ok := il.PopBack(); !ok || k != "c" || v != 3 {
+		t.Errorf("expected c=3 evicted, got %v=%v", k, v)
+	}
+	if v, ok := il.RemoveKey("b"); !ok || v != 2 || il.Contains("b") {
+		t.Errorf("expected b removed, got (%v, %v)", v, ok)
+	}
+	if _, ok := il.RemoveKey("zz"); ok {
+		t.Errorf("expected missing key removal to fail")
+	}
+	var keys []string
+	for k := range il.All() {
+		keys = append(keys, k)
+	}
+	if fmt.Sprint(keys) != "[a]" || il.Len() != 1 {
+		t.Errorf("expected [a], got %v", keys)
+	}
+}
+
+func TestRankedList(t *testing.T) {
+	r := NewRanked[int]()
+	var ref []int
+	rng := rand.New(rand.NewPCG(3, 4))
+	for step := 0; step < 3000; step++ {
+		switch op := rng.IntN(4); {
+		case op < 2 || len(ref) == 0:
+			i, v := rng.IntN(len(ref)+1), rng.Int()
+			r.InsertAt(i, v)
+			ref = slices.Insert(ref, i, v)
+		case op == 2:
+			i := rng.IntN(len(ref))
+			got, ok := r.RemoveAt(i)
+			if !ok || got != ref[i] {
+				t.Fatalf("RemoveAt(%d): expected %v, got %v", i, ref[i], got)
+			}
+			ref = slices.Delete(ref, i, i+1)
+		default:
+			i := rng.IntN(len(ref))
+			r.Set(i, -step)
+			ref[i] = -step
+		}
+		if r.Len() != len(ref) {
+			t.Fatalf("expected length %d, got %d", len(ref), r.Len())
+		}
+	}
+	for i, v := range ref {
+		if got, ok := r.At(i); !ok || got != v {
+			t.Fatalf("At(%d): expected %v, got %v", i, v, got)
+		}
+	}
+	if !slices.Equal(r.ToSlice(), ref) {
+		t.Errorf("ToSlice does not match reference")
+	}
+	if _, ok := r.At(len(ref)); ok {
+		t.Errorf("expected out of range At to fail")
+	}
+}
+
+func TestFindSorted(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	l := New[int]()
+	for i := 0; i < 200; i++ {
+		l.PushBack(i * 2)
+	}
+	if n, ok := l.FindSorted(100, less); !ok || n.Value != 100 {
+		t.Errorf("expected to find 100, got %v", n)
+	}
+	if n, ok := l.FindSorted(101, less); ok || n.Value != 102 {
+		t.Errorf("expected insertion point 102, got %v", n)
+	}
+	if n, ok := l.FindSorted(1000, less); ok || n != nil {
+		t.Errorf("expected nil past the end, got %v", n)
+	}
+
+	rng := rand.New(rand.NewPCG(5, 6))
+	for i := 0; i < 500; i++ {
+		v := rng.IntN(600) - 100
+		l.InsertOrdered(v, less)
+		if n, ok := l.FindSorted(v, less); !ok || n.Value != v {
+			t.Fatalf("expected to find %v after insert", v)
+		}
+	}
+	if got := l.ToSlice(); !slices.IsSorted(got) || len(got) != 700 {
+		t.Errorf("list not sorted after indexed inserts")
+	}
+	if err := l.Validate(); err != nil {
+		t.Errorf("invalid list: %v", err)
+	}
+
+	idx := l.sidx
+	for v := 1000; v < 1500; v++ {
+		l.InsertOrdered(v, less)
+		if n, ok := l.FindSorted(v, less); !ok || n.Value != v {
+			t.Fatalf("expected to find %v after an ascending insert", v)
+		}
+	}
+	if l.sidx != idx {
+		t.Errorf("expected ascending inserts to split gaps rather than rebuild the index")
+	}
+	e := l.Front()
+	for j, m := range idx.marks {
+		if e != m || idx.gaps[j] > 2*sortedIndexStride {
+			t.Fatalf("index out of step with the list at mark %d", j)
+		}
+		for range idx.gaps[j] {
+			e = e.Next()
+		}
+	}
+	if e != nil {
+		t.Errorf("index gaps do not cover the list")
+	}
+}
+
+func TestPopFrontBack(t *testing.T) {
+	l := New[int]()
+	l.FromSlice([]int{1, 2, 3})
+	if v, ok := l.PopFront(); !ok || v != 1 {
+		t.Errorf("expected (1, true), got (%v, %v)", v, ok)
+	}
+	if v, ok := l.PopBack(); !ok || v != 3 {
+		t.Errorf("expected (3, true), got (%v, %v)", v, ok)
+	}
+	l.PopBack()
+	if _, ok := l.PopFront(); ok || l.Len() != 0 {
+		t.Errorf("expected empty list")
+	}
+}
+
+func TestMaxLen(t *testing.T) {
+	var evicted []int
+	l := New(WithMaxLen(3, func(v int) { evicted = append(evicted, v) }))
+	for i := 1; i <= 5; i++ {
+		l.PushBack(i)
+	}
+	if got := l.String(); got != "[3 4 5]" || fmt.Sprint(evicted) != "[1 2]" {
+		t.Errorf("expected [3 4 5] evicting [1 2], got %v evicting %v", got, evicted)
+	}
+	l.PushFront(0)
+	if got := l.String(); got != "[0 3 4]" || fmt.Sprint(evicted) != "[1 2 5]" {
+		t.Errorf("expected [0 3 4] evicting [1 2 5], got %v evicting %v", got, evicted)
+	}
+	l.PushBackSlice([]int{7, 8})
+	if got := l.String(); got != "[4 7 8]" || l.Len() != 3 || l.MaxLen() != 3 {
+		t.Errorf("expected [4 7 8], got %v", got)
+	}
+	if c := l.Clone(); c.MaxLen() != 3 {
+		t.Errorf("expected clone to keep the cap")
+	}
+}
+
+func TestOrderedList(t *testing.T) {
+	o := NewOrdered[int]()
+	if _, ok := o.Min(); ok {
+		t.Errorf("expected Min of empty list to fail")
+	}
+	o.FromSlice([]int{5, 2, 8, 1})
+	if v, _ := o.Min(); v != 1 {
+		t.Errorf("expected min 1, got %v", v)
+	}
+	if v, _ := o.Max(); v != 8 {
+		t.Errorf("expected max 8, got %v", v)
+	}
+	o.Sort()
+	o.InsertOrdered(4)
+	if got := o.String(); got != "[1 2 4 5 8]" {
+		t.Errorf("expected [1 2 4 5 8], got %v", got)
+	}
+	if n, ok := o.FindSorted(5); !ok || n.Value != 5 {
+		t.Errorf("expected to find 5")
+	}
+}
+
+func TestDetachReattach(t *testing.T) {
+	l := NewPooled[int]()
+	l.FromSlice([]int{1, 2, 3})
+	two := l.Front().Next()
+
+	if !two.Detach() || two.Detach() {
+		t.Errorf("expected only the first Detach to succeed")
+	}
+	if got := l.String(); got != "[1 3]" || l.Len() != 2 {
+		t.Errorf("expected [1 3], got %v", got)
+	}
+	l.PushBack(4) // must not reuse the detached node
+	if two.Value != 2 {
+		t.Errorf("detached node was recycled")
+	}
+	if l.ReattachAfter(l.Back(), two) != two {
+		t.Errorf("expected reattach to succeed")
+	}
+	if l.ReattachAfter(nil, two) != nil {
+		t.Errorf("expected reattach of an attached node to fail")
+	}
+	if got := l.String(); got != "[1 3 4 2]" || l.Back() != two {
+		t.Errorf("expected [1 3 4 2], got %v", got)
+	}
+	if err := l.Validate(); err != nil {
+		t.Errorf("invalid list: %v", err)
+	}
+}
+
+func TestMiddleAndNthFromEnd(t *testing.T) {
+	l := New[int]()
+	if l.Middle() != nil || l.NthFromEnd(0) != nil {
+		t.Errorf("expected nil on empty list")
+	}
+	l.FromSlice([]int{1, 2, 3, 4, 5})
+	if m := l.Middle(); m.Value != 3 {
+		t.Errorf("expected middle 3, got %v", m.Value)
+	}
+	l.PushBack(6)
+	if m := l.Middle(); m.Value != 4 {
+		t.Errorf("expected middle 4, got %v", m.Value)
+	}
+	if n := l.NthFromEnd(0); n != l.Back() {
+		t.Errorf("expected back, got %v", n.Value)
+	}
+	if n := l.NthFromEnd(5); n != l.Front() {
+		t.Errorf("expected front, got %v", n)
+	}
+	if l.NthFromEnd(6) != nil || l.NthFromEnd(-1) != nil {
+		t.Errorf("expected nil when out of range")
+	}
+}
+
+func TestArena(t *testing.T) {
+	l := NewArena[int]()
+	for i := 0; i < 1000; i++ {
+		l.PushBack(i)
+	}
+	l.PushBackSlice([]int{-1, -2})
+	l.RemoveIf(func(v int) bool { return v%2 == 1 })
+	stale := l.Front()
+	if l.Len() != 502 || l.Validate() != nil {
+		t.Errorf("unexpected arena list state: len=%v", l.Len())
+	}
+
+	l.Clear()
+	if l.Len() != 0 || l.Front() != nil {
+		t.Errorf("expected empty list after Clear")
+	}
+	if _, ok := l.Remove(stale); ok || stale.Detach() {
+		t.Errorf("stale node must not be accepted after Clear")
+	}
+	l.FromSlice([]int{1, 2, 3})
+	if got := l.String(); got != "[1 2 3]" || l.Validate() != nil {
+		t.Errorf("expected [1 2 3], got %v", got)
+	}
+}
+
+func TestConcurrentList(t *testing.T) {
+	c := NewConcurrent[int]()
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := w; i < 800; i += 8 {
+				c.Insert(i)
+			}
+			for i := w; i < 800; i += 8 {
+				if i%2 == 0 && !c.Remove(i) {
+					t.Errorf("expected to remove %d", i)
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	if c.Len() != 400 || c.Contains(10) || !c.Contains(11) {
+		t.Errorf("unexpected contents, len=%v", c.Len())
+	}
+	if c.Insert(11) || c.Remove(10) {
+		t.Errorf("expected duplicate insert and missing remove to fail")
+	}
+	got := slices.Collect(c.All())
+	if len(got) != 400 || !slices.IsSorted(got) || got[0] != 1 {
+		t.Errorf("expected 400 sorted odd values")
+	}
+}
+
+func TestHooks(t *testing.T) {
+	live := map[*Node[int]]bool{}
+	l := New(
+		WithHooks(
+			func(n *Node[int]) { live[n] = true },
+			func(n *Node[int]) { delete(live, n) },
+		),
+		WithMaxLen[int](4, nil),
+	)
+	l.PushBack(1)
+	l.PushFrontSlice([]int{-1, 0})
+	l.InsertAfter(l.Front(), 5)
+	l.PushBack(2) // evicts the front
+	l.RemoveIf(func(v int) bool { return v == 5 })
+	other := New[int]()
+	other.PushBack(3)
+	l.Concat(other)
+
+	if len(live) != l.Len() {
+		t.Fatalf("hook index has %d nodes, list has %d", len(live), l.Len())
+	}
+	for n := range l.Nodes() {
+		if !live[n] {
+			t.Errorf("node %v missing from hook index", n.Value)
+		}
+	}
+	clone := l.Clone()
+	clone.Clear()
+	l.Clear()
+	if len(live) != 0 {
+		t.Errorf("expected hook index empty after Clear, got %d", len(live))
+	}
+
+	for _, arena := range []bool{false, true} {
+		var lens []int
+		var l *List[int]
+		hooks := WithHooks(nil, func(n *Node[int]) { lens = append(lens, l.Len()) })
+		if arena {
+			l = New(hooks, WithArena[int](2))
+		} else {
+			l = New(hooks)
+		}
+		l.PushBackSlice([]int{1, 2, 3})
+		l.Clear()
+		if got := fmt.Sprint(lens); got != "[2 1 0]" || l.Len() != 0 {
+			t.Errorf("arena=%v: expected Len [2 1 0] seen from onRemove during Clear, got %v", arena, got)
+		}
+	}
+}
+
+func TestFromSeq(t *testing.T) {
+	src := New[int]()
+	src.FromSlice([]int{1, 2, 3})
+	l := FromSeq(src.Backward())
+	l.AppendSeq(slices.Values([]int{0}))
+	if got := l.String(); got != "[3 2 1 0]" || l.Len() != 4 {
+		t.Errorf("expected [3 2 1 0], got %v", got)
+	}
+	if FromSeq(slices.Values([]int(nil))).Len() != 0 {
+		t.Errorf("expected empty list from empty sequence")
+	}
+}
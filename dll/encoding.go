@@ -0,0 +1,85 @@
+package dll
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+	"fmt"
+)
+
+// Binary layout produced by MarshalBinary:
+//
+//	magic   [3]byte  "DLL"
+//	version byte     currently 1
+//	length  uvarint  number of elements
+//	values  gob      one gob-encoded value per element, front to back
+//
+// Decoders reject versions newer than the one they understand so the format
+// can evolve without old readers silently misinterpreting new data.
+const (
+	binaryMagic   = "DLL"
+	binaryVersion = 1
+)
+
+// ErrInvalidEncoding is returned by UnmarshalBinary when the input is not a
+// list encoded by MarshalBinary.
+var ErrInvalidEncoding = errors.New("dll: invalid binary encoding")
+
+// MarshalBinary implements encoding.BinaryMarshaler. Element values are
+// encoded with encoding/gob, so T must be gob-encodable.
+func (l *List[T]) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(binaryMagic)
+	buf.WriteByte(binaryVersion)
+	var lenBuf [binary.MaxVarintLen64]byte
+	buf.Write(lenBuf[:binary.PutUvarint(lenBuf[:], uint64(l.len))])
+
+	enc := gob.NewEncoder(&buf)
+	for e := l.head; e != nil; e = e.next {
+		if err := enc.Encode(e.Value); err != nil {
+			return nil, fmt.Errorf("dll: encode element: %w", err)
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. It replaces the list
+// contents with the decoded elements. On error the list is left unchanged.
+func (l *List[T]) UnmarshalBinary(data []byte) error {
+	if len(data) < len(binaryMagic)+1 || string(data[:len(binaryMagic)]) != binaryMagic {
+		return ErrInvalidEncoding
+	}
+	data = data[len(binaryMagic):]
+	if v := data[0]; v == 0 || v > binaryVersion {
+		return fmt.Errorf("dll: unsupported encoding version %d", v)
+	}
+	data = data[1:]
+	n, k := binary.Uvarint(data)
+	if k <= 0 {
+		return ErrInvalidEncoding
+	}
+	data = data[k:]
+
+	// Every gob value takes at least one byte, which bounds a corrupt length.
+	if n > uint64(len(data)) {
+		return ErrInvalidEncoding
+	}
+	values := make([]T, 0, n)
+	dec := gob.NewDecoder(bytes.NewReader(data))
+	for i := uint64(0); i < n; i++ {
+		var v T
+		if err := dec.Decode(&v); err != nil {
+			return fmt.Errorf("dll: decode element %d: %w", i, err)
+		}
+		values = append(values, v)
+	}
+	l.FromSlice(values)
+	return nil
+}
+
+// GobEncode implements gob.GobEncoder using the MarshalBinary format.
+func (l *List[T]) GobEncode() ([]byte, error) { return l.MarshalBinary() }
+
+// GobDecode implements gob.GobDecoder using the UnmarshalBinary format.
+func (l *List[T]) GobDecode(data []byte) error { return l.UnmarshalBinary(data) }
@@ -0,0 +1,154 @@
+package dll
+
+import "iter"
+
+// IndexedList is a list of key/value pairs with an index from key to node,
+// so lookups, reordering, and removal by key are all O(1). It is the usual
+// backbone of LRU and MRU caches. Keys are unique within the list.
+//
+// Like List, it is NOT safe for concurrent use.
+type IndexedList[K comparable, T any] struct {
+	list  *List[entry[K, T]]
+	index map[K]*Node[entry[K, T]]
+}
+
+type entry[K comparable, T any] struct {
+	key   K
+	value T
+}
+
+// NewIndexed returns an empty indexed list.
+func NewIndexed[K comparable, T any]() *IndexedList[K, T] {
+	return &IndexedList[K, T]{
+		list:  New[entry[K, T]](),
+		index: make(map[K]*Node[entry[K, T]]),
+	}
+}
+
+// Len returns the number of entries.
+func (il *IndexedList[K, T]) Len() int { return il.list.Len() }
+
+// Contains reports whether key is present.
+func (il *IndexedList[K, T]) Contains(key K) bool {
+	_, ok := il.index[key]
+	return ok
+}
+
+// Get returns the value stored under key without changing its position.
+func (il *IndexedList[K, T]) Get(key K) (T, bool) {
+	n, ok := il.index[key]
+	if !ok {
+		var zero T
+		return zero, false
+	}
+	return n.Value.value, true
+}
+
+// PushFront stores v under key at the front. If key is already present its
+// value is replaced and the entry moves to the front. It reports whether a
+// new entry was added.
+func (il *IndexedList[K, T]) PushFront(key K, v T) bool {
+	if n, ok := il.index[key]; ok {
+		n.Value.value = v
+		il.list.MoveToFront(n)
+		return false
+	}
+	il.index[key] = il.list.PushFront(entry[K, T]{key: key, value: v})
+	return true
+}
+
+// PushBack stores v under key at the back. If key is already present its
+// value is replaced and the entry moves to the back. It reports whether a
+// new entry was added.
+func (il *IndexedList[K, T]) PushBack(key K, v T) bool {
+	if n, ok := il.index[key]; ok {
+		n.Value.value = v
+		il.list.MoveToBack(n)
+		return false
+	}
+	il.index[key] = il.list.PushBack(entry[K, T]{key: key, value: v})
+	return true
+}
+
+// MoveToFront moves the entry for key to the front and reports whether it
+// was found.
+func (il *IndexedList[K, T]) MoveToFront(key K) bool {
+	n, ok := il.index[key]
+	if ok {
+		il.list.MoveToFront(n)
+	}
+	return ok
+}
+
+// MoveToBack moves the entry for key to the back and reports whether it was
+// found.
+func (il *IndexedList[K, T]) MoveToBack(key K) bool {
+	n, ok := il.index[key]
+	if ok {
+		il.list.MoveToBack(n)
+	}
+	return ok
+}
+
+// RemoveKey removes the entry for key and returns its value.
+func (il *IndexedList[K, T]) RemoveKey(key K) (T, bool) {
+	n, ok := il.index[key]
+	if !ok {
+		var zero T
+		return zero, false
+	}
+	delete(il.index, key)
+	e, _ := il.list.Remove(n)
+	return e.value, true
+}
+
+// Front returns the key and value at the front.
+func (il *IndexedList[K, T]) Front() (K, T, bool) { return il.peek(il.list.Front()) }
+
+// Back returns the key and value at the back.
+func (il *IndexedList[K, T]) Back() (K, T, bool) { return il.peek(il.list.Back()) }
+
+// PopFront removes and returns the entry at the front.
+func (il *IndexedList[K, T]) PopFront() (K, T, bool) { return il.pop(il.list.Front()) }
+
+// PopBack removes and returns the entry at the back, which for an LRU cache
+// is the eviction candidate.
+func (il *IndexedList[K, T]) PopBack() (K, T, bool) { return il.pop(il.list.Back()) }
+
+// Clear removes all entries.
+func (il *IndexedList[K, T]) Clear() {
+	il.list.Clear()
+	clear(il.index)
+}
+
+// All returns an iterator over the entries from front to back. Like
+// List.All, it panics if the list is structurally modified mid-iteration.
+func (il *IndexedList[K, T]) All() iter.Seq2[K, T] {
+	return func(yield func(K, T) bool) {
+		for e := range il.list.All() {
+			if !yield(e.key, e.value) {
+				return
+			}
+		}
+	}
+}
+
+func (il *IndexedList[K, T]) peek(n *Node[entry[K, T]]) (K, T, bool) {
+	if n == nil {
+		var (
+			k K
+			v T
+		)
+		return k, v, false
+	}
+	return n.Value.key, n.Value.value, true
+}
+
+func (il *IndexedList[K, T]) pop(n *Node[entry[K, T]]) (K, T, bool) {
+	k, v, ok := il.peek(n)
+	if ok {
+		delete(il.index, k)
+		il.list.Remove(n)
+	}
+	return k, v, ok
+}
@@ -0,0 +1,113 @@
+package dll
+
+// Option configures a List at construction time.
+type Option[T any] func(*List[T])
+
+// config holds the settings applied by Options. Lists derived from another
+// list, such as clones and partitions, inherit its config.
+type config[T any] struct {
+	pool       *NodePool[T]
+	arenaBlock int // nodes per arena slab, 0 disables the arena
+	maxLen     int // 0 means unbounded
+	onEvict    func(T)
+	onInsert   func(*Node[T])
+	onRemove   func(*Node[T])
+}
+
+// WithMaxLen caps the list at max elements. When an insertion would exceed
+// the cap, elements are evicted from the opposite end: insertions at the
+// front (PushFront, PushFrontSlice, PushFrontList, InsertBefore the front
+// node) evict from the back, and every other insertion evicts from the front.
+// onEvict, if non-nil, is called with each evicted value. A max of zero or
+// less means unbounded.
+//
+// Eviction happens after the insertion, so inserting at the evicting end of a
+// full list evicts the new value itself.
+func WithMaxLen[T any](max int, onEvict func(T)) Option[T] {
+	return func(l *List[T]) {
+		l.cfg.maxLen = max
+		l.cfg.onEvict = onEvict
+	}
+}
+
+// MaxLen returns the length cap set by WithMaxLen, or 0 if unbounded.
+func (l *List[T]) MaxLen() int { return l.cfg.maxLen }
+
+// evict removes elements from the front (or the back) until the list is
+// within its length cap.
+func (l *List[T]) evict(fromFront bool) {
+	if l.cfg.maxLen <= 0 {
+		return
+	}
+	for l.len > l.cfg.maxLen {
+		n := l.tail
+		if fromFront {
+			n = l.head
+		}
+		v := n.Value
+		l.discard(n)
+		if l.cfg.onEvict != nil {
+			l.cfg.onEvict(v)
+		}
+	}
+}
+
+// WithHooks registers callbacks fired when nodes enter or leave the list, so
+// higher-level structures can keep secondary state such as indexes in sync.
+// onInsert runs after a node has been linked in; onRemove runs after a node
+// has been unlinked, so Len no longer counts it, but before it is recycled,
+// so its Value is still readable. Nodes moved between lists fire the
+// source's onRemove and the destination's onInsert. Either callback may be
+// nil. Hooks must not modify the list. Lists derived from this one, such as
+// clones, do not inherit the hooks.
+func WithHooks[T any](onInsert, onRemove func(*Node[T])) Option[T] {
+	return func(l *List[T]) {
+		l.cfg.onInsert = onInsert
+		l.cfg.onRemove = onRemove
+	}
+}
+
+// derived returns an empty list with l's configuration minus its hooks.
+func (l *List[T]) derived() *List[T] {
+	cfg := l.cfg
+	cfg.onInsert, cfg.onRemove = nil, nil
+	return &List[T]{cfg: cfg}
+}
+
+// added fires the insert hook for the run head..tail, which has just been
+// linked into l, and then enforces the length cap.
+func (l *List[T]) added(head, tail *Node[T], fromFront bool) {
+	if l.cfg.onInsert != nil {
+		for e := head; ; e = e.next {
+			l.cfg.onInsert(e)
+			if e == tail {
+				break
+			}
+		}
+	}
+	l.evict(fromFront)
+}
+
+// discard unlinks n, fires the remove hook, and releases n.
+func (l *List[T]) discard(n *Node[T]) {
+	l.unlink(n)
+	if l.cfg.onRemove != nil {
+		l.cfg.onRemove(n)
+	}
+	l.release(n)
+}
+
+// hooksTransfer reports whether moving nodes from other into l fires hooks.
+func (l *List[T]) hooksTransfer(other *List[T]) bool {
+	return other.cfg.onRemove != nil || l.cfg.onInsert != nil
+}
+
+// transferred fires the hooks for node n, which has moved from other into l.
+func (l *List[T]) transferred(other *List[T], n *Node[T]) {
+	if other.cfg.onRemove != nil {
+		other.cfg.onRemove(n)
+	}
+	if l.cfg.onInsert != nil {
+		l.cfg.onInsert(n)
+	}
+}
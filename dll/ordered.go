@@ -0,0 +1,55 @@
+package dll
+
+import "cmp"
+
+// OrderedList is a List of cmp.Ordered values whose ordering operations use
+// the natural order, so callers don't pass a less function each time. All
+// List methods remain available.
+type OrderedList[T cmp.Ordered] struct {
+	*List[T]
+}
+
+// NewOrdered returns an empty ordered list configured by opts.
+func NewOrdered[T cmp.Ordered](opts ...Option[T]) *OrderedList[T] {
+	return &OrderedList[T]{List: New(opts...)}
+}
+
+// Sort sorts the list in ascending order.
+func (o *OrderedList[T]) Sort() { o.SortFunc(cmp.Less[T]) }
+
+// InsertOrdered inserts v at its ascending position, after any equal values,
+// and returns the new node. The list must already be sorted.
+func (o *OrderedList[T]) InsertOrdered(v T) *Node[T] {
+	return o.List.InsertOrdered(v, cmp.Less[T])
+}
+
+// FindSorted locates v in the sorted list; see List.FindSorted.
+func (o *OrderedList[T]) FindSorted(v T) (*Node[T], bool) {
+	return o.List.FindSorted(v, cmp.Less[T])
+}
+
+// Min returns the smallest value, scanning the whole list so it works
+// whether or not the list is sorted.
+func (o *OrderedList[T]) Min() (T, bool) { return o.extreme(cmp.Less[T]) }
+
+// Max returns the largest value, scanning the whole list so it works
+// whether or not the list is sorted.
+func (o *OrderedList[T]) Max() (T, bool) {
+	return o.extreme(func(a, b T) bool { return cmp.Less(b, a) })
+}
+
+// extreme returns the first value that no other value is better than.
+func (o *OrderedList[T]) extreme(better func(a, b T) bool) (T, bool) {
+	e := o.Front()
+	if e == nil {
+		var zero T
+		return zero, false
+	}
+	best := e.Value
+	for e = e.Next(); e != nil; e = e.Next() {
+		if better(e.Value, best) {
+			best = e.Value
+		}
+	}
+	return best, true
+}
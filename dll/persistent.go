@@ -0,0 +1,81 @@
+package dll
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Persistent is an immutable singly-linked list with structural sharing.
+// Every operation returns a new Persistent and leaves the receiver intact, so
+// a value can be handed to concurrent readers as a stable snapshot without
+// locking. The zero value is an empty list.
+type Persistent[T any] struct {
+	head *cell[T]
+	len  int
+}
+
+type cell[T any] struct {
+	value T
+	next  *cell[T]
+}
+
+// PushFront returns a list with v in front of p's elements. It is O(1) and
+// shares all of p's cells.
+func (p Persistent[T]) PushFront(v T) Persistent[T] {
+	return Persistent[T]{head: &cell[T]{value: v, next: p.head}, len: p.len + 1}
+}
+
+// PopFront returns the front element and the list of the remaining elements.
+// If p is empty it returns the zero value, p, and false.
+func (p Persistent[T]) PopFront() (T, Persistent[T], bool) {
+	if p.head == nil {
+		var zero T
+		return zero, p, false
+	}
+	return p.head.value, Persistent[T]{head: p.head.next, len: p.len - 1}, true
+}
+
+// Front returns the front element without removing it.
+func (p Persistent[T]) Front() (T, bool) {
+	if p.head == nil {
+		var zero T
+		return zero, false
+	}
+	return p.head.value, true
+}
+
+// Reverse returns a list with p's elements in reverse order. It allocates n
+// new cells, since no suffix can be shared.
+func (p Persistent[T]) Reverse() Persistent[T] {
+	var out Persistent[T]
+	for c := p.head; c != nil; c = c.next {
+		out = out.PushFront(c.value)
+	}
+	return out
+}
+
+// Len returns the number of elements.
+func (p Persistent[T]) Len() int { return p.len }
+
+// ToSlice returns a slice with the list elements in order.
+func (p Persistent[T]) ToSlice() []T {
+	out := make([]T, 0, p.len)
+	for c := p.head; c != nil; c = c.next {
+		out = append(out, c.value)
+	}
+	return out
+}
+
+// String returns a string representation of the list values.
+func (p Persistent[T]) String() string {
+	var sb strings.Builder
+	sb.WriteString("[")
+	for c := p.head; c != nil; c = c.next {
+		sb.WriteString(fmt.Sprintf("%v", c.value))
+		if c.next != nil {
+			sb.WriteString(" ")
+		}
+	}
+	sb.WriteString("]")
+	return sb.String()
+}
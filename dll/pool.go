@@ -0,0 +1,66 @@
+package dll
+
+import "sync"
+
+// NodePool recycles list nodes through a sync.Pool. A single pool may be
+// shared by many lists of the same element type, which pays off when lists
+// are created and discarded at a high rate.
+type NodePool[T any] struct {
+	p sync.Pool
+}
+
+// NewNodePool returns an empty node pool.
+func NewNodePool[T any]() *NodePool[T] {
+	return &NodePool[T]{p: sync.Pool{New: func() any { return new(Node[T]) }}}
+}
+
+// WithNodePool makes the list take nodes from p and return removed nodes to
+// it.
+//
+// In pooled mode a node must not be used once it has been removed from the
+// list (by Remove, Clear, or any other removing operation): it may already
+// hold another element of this or another list.
+func WithNodePool[T any](p *NodePool[T]) Option[T] {
+	return func(l *List[T]) { l.cfg.pool = p }
+}
+
+// NewPooled returns an empty list that recycles its nodes through a private
+// NodePool. See WithNodePool for the restrictions this places on callers.
+func NewPooled[T any]() *List[T] {
+	return New(WithNodePool(NewNodePool[T]()))
+}
+
+// newNode returns a node owned by l holding v, taken from the arena or node
+// pool when one is configured.
+func (l *List[T]) newNode(v T) *Node[T] {
+	var n *Node[T]
+	switch {
+	case l.cfg.arenaBlock > 0:
+		n = l.arenaAlloc()
+	case l.cfg.pool != nil:
+		n = l.cfg.pool.p.Get().(*Node[T])
+	default:
+		n = new(Node[T])
+	}
+	n.Value = v
+	n.owner = l.ident()
+	return n
+}
+
+// release clears a node that has been unlinked from l and, in arena or
+// pooled mode, makes it available for reuse.
+func (l *List[T]) release(n *Node[T]) {
+	n.prev = nil
+	n.next = nil
+	n.owner = nil
+	if l.cfg.arenaBlock <= 0 && l.cfg.pool == nil {
+		return
+	}
+	var zero T
+	n.Value = zero
+	if l.cfg.arenaBlock > 0 {
+		l.arenaFree(n)
+	} else {
+		l.cfg.pool.p.Put(n)
+	}
+}
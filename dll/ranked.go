@@ -0,0 +1,182 @@
+package dll
+
+import (
+	"iter"
+	"math/rand/v2"
+)
+
+// rankedMaxLevel caps the tower height; with p = 1/4 it comfortably covers
+// lists far larger than memory allows.
+const rankedMaxLevel = 24
+
+// RankedList is a sequence backed by an indexable skip list: every forward
+// link records how many positions it spans, so At, Set, InsertAt, and
+// RemoveAt run in expected O(log n) instead of the O(n) walk a List needs.
+// Use it when positional access dominates; List remains cheaper for pure
+// end operations and node-based splicing.
+//
+// Like List, it is NOT safe for concurrent use.
+type RankedList[T any] struct {
+	head  rankedNode[T] // sentinel, position 0
+	level int
+	len   int
+}
+
+type rankedNode[T any] struct {
+	value T
+	next  []rankedLink[T]
+}
+
+type rankedLink[T any] struct {
+	node  *rankedNode[T]
+	width int // positions advanced by following this link
+}
+
+// NewRanked returns an empty ranked list.
+func NewRanked[T any]() *RankedList[T] {
+	r := &RankedList[T]{level: 1}
+	r.head.next = make([]rankedLink[T], rankedMaxLevel)
+	return r
+}
+
+// Len returns the number of elements.
+func (r *RankedList[T]) Len() int { return r.len }
+
+// At returns the element at index i (0 is the front).
+func (r *RankedList[T]) At(i int) (T, bool) {
+	if i < 0 || i >= r.len {
+		var zero T
+		return zero, false
+	}
+	return r.find(i).value, true
+}
+
+// Set replaces the element at index i and reports whether i was in range.
+func (r *RankedList[T]) Set(i int, v T) bool {
+	if i < 0 || i >= r.len {
+		return false
+	}
+	r.find(i).value = v
+	return true
+}
+
+// PushFront inserts v at the front.
+func (r *RankedList[T]) PushFront(v T) { r.InsertAt(0, v) }
+
+// PushBack inserts v at the back.
+func (r *RankedList[T]) PushBack(v T) { r.InsertAt(r.len, v) }
+
+// InsertAt inserts v so that it ends up at index i, shifting later elements
+// back by one. i may equal Len to append. It reports whether i was in range.
+func (r *RankedList[T]) InsertAt(i int, v T) bool {
+	if i < 0 || i > r.len {
+		return false
+	}
+	var update [rankedMaxLevel]*rankedNode[T]
+	var rank [rankedMaxLevel]int
+	x, pos := &r.head, 0
+	for lvl := r.level - 1; lvl >= 0; lvl-- {
+		for l := x.next[lvl]; l.node != nil && pos+l.width <= i; l = x.next[lvl] {
+			pos += l.width
+			x = l.node
+		}
+		update[lvl], rank[lvl] = x, pos
+	}
+
+	h := randomRankedLevel()
+	for ; r.level < h; r.level++ {
+		update[r.level], rank[r.level] = &r.head, 0
+		r.head.next[r.level] = rankedLink[T]{width: r.len}
+	}
+	n := &rankedNode[T]{value: v, next: make([]rankedLink[T], h)}
+	for lvl := 0; lvl < h; lvl++ {
+		prev := update[lvl]
+		n.next[lvl] = rankedLink[T]{node: prev.next[lvl].node, width: rank[lvl] + prev.next[lvl].width - i}
+		prev.next[lvl] = rankedLink[T]{node: n, width: i + 1 - rank[lvl]}
+	}
+	for lvl := h; lvl < r.level; lvl++ {
+		update[lvl].next[lvl].width++
+	}
+	r.len++
+	return true
+}
+
+// RemoveAt removes and returns the element at index i, shifting later
+// elements forward by one.
+func (r *RankedList[T]) RemoveAt(i int) (T, bool) {
+	if i < 0 || i >= r.len {
+		var zero T
+		return zero, false
+	}
+	var update [rankedMaxLevel]*rankedNode[T]
+	x, pos := &r.head, 0
+	for lvl := r.level - 1; lvl >= 0; lvl-- {
+		for l := x.next[lvl]; l.node != nil && pos+l.width <= i; l = x.next[lvl] {
+			pos += l.width
+			x = l.node
+		}
+		update[lvl] = x
+	}
+	target := update[0].next[0].node
+	for lvl := 0; lvl < r.level; lvl++ {
+		prev := update[lvl]
+		if prev.next[lvl].node == target {
+			prev.next[lvl] = rankedLink[T]{
+				node:  target.next[lvl].node,
+				width: prev.next[lvl].width + target.next[lvl].width - 1,
+			}
+		} else {
+			prev.next[lvl].width--
+		}
+	}
+	for r.level > 1 && r.head.next[r.level-1].node == nil {
+		r.level--
+	}
+	r.len--
+	return target.value, true
+}
+
+// All returns an iterator over the elements from front to back.
+func (r *RankedList[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for x := r.head.next[0].node; x != nil; x = x.next[0].node {
+			if !yield(x.value) {
+				return
+			}
+		}
+	}
+}
+
+// ToSlice returns a slice with the elements in order.
+func (r *RankedList[T]) ToSlice() []T {
+	out := make([]T, 0, r.len)
+	for v := range r.All() {
+		out = append(out, v)
+	}
+	return out
+}
+
+// find returns the node at index i, which must be in range.
+func (r *RankedList[T]) find(i int) *rankedNode[T] {
+	target := i + 1
+	x, pos := &r.head, 0
+	for lvl := r.level - 1; lvl >= 0; lvl-- {
+		for l := x.next[lvl]; l.node != nil && pos+l.width <= target; l = x.next[lvl] {
+			pos += l.width
+			x = l.node
+		}
+		if pos == target {
+			break
+		}
+	}
+	return x
+}
+
+// randomRankedLevel draws a tower height with P(h > k) = 4^-k.
+func randomRankedLevel() int {
+	h := 1
+	for h < rankedMaxLevel && rand.Uint32()&3 == 0 {
+		h++
+	}
+	return h
+}
@@ -0,0 +1,107 @@
+package dll
+
+import (
+	"slices"
+	"sort"
+)
+
+// sortedIndexStride is the distance between sampled nodes in a jump index.
+const sortedIndexStride = 32
+
+// sortedIndex samples every sortedIndexStride-th node of a sorted list so
+// lookups can binary search the samples and then scan a single short gap.
+// It is valid only while mod matches the list's modification counter.
+type sortedIndex[T any] struct {
+	mod   uint64
+	marks []*Node[T] // marks[0] is always the head
+	gaps  []int      // gaps[j] counts nodes from marks[j] up to the next mark
+}
+
+// FindSorted locates v in a list sorted by less. It returns the first node
+// whose value is not less than v, which is where v would be inserted, and
+// reports whether that node's value is equal to v. If every value is less
+// than v it returns nil and false.
+//
+// The first call after a structural modification builds a jump index in
+// O(n); later calls take O(log n) comparisons plus a short scan. InsertOrdered
+// keeps the index current, so alternating lookups and ordered inserts stay
+// fast, while any other modification discards it.
+func (l *List[T]) FindSorted(v T, less func(a, b T) bool) (*Node[T], bool) {
+	n, _ := l.sortedIndex().search(func(x T) bool { return !less(x, v) })
+	return n, n != nil && !less(v, n.Value)
+}
+
+// sortedIndex returns a current jump index, rebuilding it if needed.
+func (l *List[T]) sortedIndex() *sortedIndex[T] {
+	if l.sidx != nil && l.sidx.mod == l.mod {
+		return l.sidx
+	}
+	idx := &sortedIndex[T]{mod: l.mod}
+	i := 0
+	for e := l.head; e != nil; e = e.next {
+		if i%sortedIndexStride == 0 {
+			idx.marks = append(idx.marks, e)
+			idx.gaps = append(idx.gaps, 0)
+		}
+		idx.gaps[len(idx.gaps)-1]++
+		i++
+	}
+	l.sidx = idx
+	return idx
+}
+
+// search returns the first node for which pred holds, given that pred is
+// false for some prefix of the list and true afterwards, along with the
+// index of the gap that node falls in.
+func (idx *sortedIndex[T]) search(pred func(T) bool) (*Node[T], int) {
+	j := sort.Search(len(idx.marks), func(i int) bool { return pred(idx.marks[i].Value) })
+	if j == 0 {
+		if len(idx.marks) == 0 {
+			return nil, 0
+		}
+		return idx.marks[0], 0
+	}
+	e := idx.marks[j-1].next
+	for e != nil && !pred(e.Value) {
+		e = e.next
+	}
+	return e, j - 1
+}
+
+// insertIndexed inserts v after any equal values using the current index,
+// then updates the index to account for the new node.
+func (l *List[T]) insertIndexed(idx *sortedIndex[T], v T, less func(a, b T) bool) *Node[T] {
+	at, gap := idx.search(func(x T) bool { return less(v, x) })
+	before := l.mod
+	var n *Node[T]
+	if at == nil {
+		n = l.PushBack(v)
+	} else {
+		n = l.InsertBefore(at, v)
+	}
+	if at == idx.marks[0] {
+		idx.marks[0] = n
+	}
+	idx.gaps[gap]++
+	idx.mod = l.mod
+	if l.mod != before+1 {
+		// The insertion evicted nodes, which may have been sampled; let the
+		// next FindSorted rebuild.
+		l.sidx = nil
+	} else if idx.gaps[gap] > 2*sortedIndexStride {
+		idx.split(gap)
+	}
+	return n
+}
+
+// split halves an oversized gap by sampling a new mark sortedIndexStride
+// nodes into it.
+func (idx *sortedIndex[T]) split(gap int) {
+	m := idx.marks[gap]
+	for range sortedIndexStride {
+		m = m.next
+	}
+	idx.marks = slices.Insert(idx.marks, gap+1, m)
+	idx.gaps = slices.Insert(idx.gaps, gap+1, idx.gaps[gap]-sortedIndexStride)
+	idx.gaps[gap] = sortedIndexStride
+}
@@ -0,0 +1,108 @@
+package priorityqueue
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrClosed is returned by BlockingPriorityQueue once it has been closed:
+// by Push at once, and by Pop after the remaining items have been drained.
+var ErrClosed = errors.New("priorityqueue: closed")
+
+// BlockingPriorityQueue is a thread-safe priority queue whose Pop blocks
+// until an item is available, effectively a priority-ordered channel for
+// worker pools. Close stops new pushes while letting consumers drain what
+// is left.
+type BlockingPriorityQueue[T any] struct {
+	mu      sync.Mutex
+	pq      *PriorityQueue[T]
+	closed  bool
+	changed chan struct{} // closed on the next change, nil if nobody waits
+}
+
+// NewBlocking creates a new empty blocking priority queue with a custom less
+// function.
+func NewBlocking[T any](less func(a, b T) bool) *BlockingPriorityQueue[T] {
+	return &BlockingPriorityQueue[T]{pq: New(less)}
+}
+
+// Push adds a Value to the queue and wakes a waiting Pop. It returns
+// ErrClosed if the queue has been closed.
+func (b *BlockingPriorityQueue[T]) Push(value T) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return ErrClosed
+	}
+	b.pq.PushValue(value)
+	b.signal()
+	return nil
+}
+
+// Pop removes and returns the top-priority Value, waiting until there is
+// one. It returns ctx.Err() if ctx is done first, and ErrClosed if the queue
+// is closed and empty.
+func (b *BlockingPriorityQueue[T]) Pop(ctx context.Context) (T, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for b.pq.Len() == 0 {
+		var zero T
+		if b.closed {
+			return zero, ErrClosed
+		}
+		if err := b.wait(ctx); err != nil {
+			return zero, err
+		}
+	}
+	v, _ := b.pq.PopValue()
+	return v, nil
+}
+
+// TryPop removes and returns the top-priority Value without waiting.
+func (b *BlockingPriorityQueue[T]) TryPop() (T, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.pq.PopValue()
+}
+
+// Close stops further pushes and wakes all waiting Pops. Items already in
+// the queue can still be popped. Closing twice is a no-op.
+func (b *BlockingPriorityQueue[T]) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+	b.signal()
+}
+
+// Len returns the number of items.
+func (b *BlockingPriorityQueue[T]) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.pq.Len()
+}
+
+// wait releases the lock until the queue changes or ctx is done, then
+// reacquires it. Callers must recheck their condition.
+func (b *BlockingPriorityQueue[T]) wait(ctx context.Context) error {
+	if b.changed == nil {
+		b.changed = make(chan struct{})
+	}
+	ch := b.changed
+	b.mu.Unlock()
+	defer b.mu.Lock()
+	select {
+	case <-ch:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// signal wakes every waiter.
+func (b *BlockingPriorityQueue[T]) signal() {
+	if b.changed != nil {
+		close(b.changed)
+		b.changed = nil
+	}
+}
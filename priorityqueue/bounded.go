@@ -0,0 +1,104 @@
+package priorityqueue
+
+// A bounded queue must find its worst item on every push once it is full.
+// The worst item can sit at any leaf of the main heap, so the queue also
+// keeps its items in worsts, a binary heap of the opposite order with the
+// worst item on top. The two heaps hold the same items; each item records
+// its position in both. Every change to the main heap goes through Push,
+// Pop, fix, init or replace, which keep worsts in step, so finding the worst
+// item is O(1) and maintaining it adds O(log k) to each operation.
+
+// wless reports whether the item in slot i of worsts has lower priority
+// than the one in slot j.
+func (pq *PriorityQueue[T]) wless(i, j int) bool {
+	return pq.itemLess(pq.worsts[j], pq.worsts[i])
+}
+
+func (pq *PriorityQueue[T]) wswap(i, j int) {
+	pq.worsts[i], pq.worsts[j] = pq.worsts[j], pq.worsts[i]
+	pq.worsts[i].windex = i
+	pq.worsts[j].windex = j
+}
+
+func (pq *PriorityQueue[T]) wup(j int) {
+	for j > 0 {
+		i := (j - 1) / 2
+		if !pq.wless(j, i) {
+			break
+		}
+		pq.wswap(i, j)
+		j = i
+	}
+}
+
+func (pq *PriorityQueue[T]) wdown(i0 int) bool {
+	n := len(pq.worsts)
+	i := i0
+	for {
+		j := 2*i + 1
+		if j >= n || j < 0 {
+			break
+		}
+		if r := j + 1; r < n && pq.wless(r, j) {
+			j = r
+		}
+		if !pq.wless(j, i) {
+			break
+		}
+		pq.wswap(i, j)
+		i = j
+	}
+	return i > i0
+}
+
+func (pq *PriorityQueue[T]) wfix(i int) {
+	if !pq.wdown(i) {
+		pq.wup(i)
+	}
+}
+
+// wpush adds it to worsts.
+func (pq *PriorityQueue[T]) wpush(it *Item[T]) {
+	it.windex = len(pq.worsts)
+	pq.worsts = append(pq.worsts, it)
+	pq.wup(it.windex)
+}
+
+// wremove removes it from worsts.
+func (pq *PriorityQueue[T]) wremove(it *Item[T]) {
+	i, n := it.windex, len(pq.worsts)-1
+	if i != n {
+		pq.wswap(i, n)
+	}
+	pq.worsts[n] = nil
+	pq.worsts = pq.worsts[:n]
+	if i != n {
+		pq.wfix(i)
+	}
+	it.windex = -1
+}
+
+// winit rebuilds worsts from the items of the main heap.
+func (pq *PriorityQueue[T]) winit() {
+	pq.worsts = append(pq.worsts[:0], pq.items...)
+	clear(pq.worsts[len(pq.worsts):cap(pq.worsts)])
+	for i, it := range pq.worsts {
+		it.windex = i
+	}
+	for i := len(pq.worsts)/2 - 1; i >= 0; i-- {
+		pq.wdown(i)
+	}
+}
+
+// replace puts it in old's place in both heaps without restoring the order;
+// the caller follows up with fix on it.index.
+func (pq *PriorityQueue[T]) replace(old, it *Item[T]) {
+	it.index = old.index
+	pq.items[it.index] = it
+	old.index = -1
+	if pq.limit > 0 {
+		it.windex = old.windex
+		pq.worsts[it.windex] = it
+		old.windex = -1
+	}
+}
@@ -0,0 +1,37 @@
+package priorityqueue
+
+import (
+	"encoding/json"
+	"slices"
+)
+
+// MarshalJSON implements json.Marshaler, encoding the values as a JSON array
+// in heap order, which is cheap and enough to restore the queue. Use
+// MarshalJSONSorted for output meant to be read.
+func (pq *PriorityQueue[T]) MarshalJSON() ([]byte, error) {
+	values := make([]T, 0, pq.Len())
+	for _, it := range pq.items {
+		if !it.dead {
+			values = append(values, it.Value)
+		}
+	}
+	return json.Marshal(values)
+}
+
+// MarshalJSONSorted is like MarshalJSON but emits the values in priority
+// order, best first.
+func (pq *PriorityQueue[T]) MarshalJSONSorted() ([]byte, error) {
+	return json.Marshal(slices.Collect(pq.Ordered()))
+}
+
+// UnmarshalInto decodes a JSON array of values, as produced by MarshalJSON
+// or MarshalJSONSorted, into a new queue ordered by less, built with a
+// single heapify. A PriorityQueue cannot implement json.Unmarshaler itself
+// because the less function is not part of the data.
+func UnmarshalInto[T any](data []byte, less func(a, b T) bool) (*PriorityQueue[T], error) {
+	var values []T
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, err
+	}
+	return NewFromSlice(values, less), nil
+}
@@ -0,0 +1,124 @@
+package priorityqueue
+
+// The queue keeps its own d-ary heap routines rather than using
+// container/heap, which only knows binary heaps. They follow container/heap
+// closely and still go through the heap.Interface methods, so PriorityQueue
+// remains usable with container/heap directly for the default arity.
+
+// SetArity makes the queue a d-ary heap, with d children per node, and
+// rearranges the current items in O(n). A wider heap is shallower, so pops
+// do fewer, more cache-friendly levels of sifting at the cost of more
+// comparisons per level; 4 is a good choice for large queues. The default
+// is 2. It panics if d < 2.
+func (pq *PriorityQueue[T]) SetArity(d int) {
+	if d < 2 {
+		panic("priorityqueue: arity must be at least 2")
+	}
+	pq.arity = d
+	pq.init()
+}
+
+// Arity returns the number of children per heap node.
+func (pq *PriorityQueue[T]) Arity() int {
+	return pq.d()
+}
+
+func (pq *PriorityQueue[T]) d() int {
+	if pq.arity == 0 {
+		return 2
+	}
+	return pq.arity
+}
+
+// child returns the index of the k-th child of node i.
+func (pq *PriorityQueue[T]) child(i, k int) int {
+	return pq.d()*i + 1 + k
+}
+
+// firstLeaf returns the index of the first node without children.
+func (pq *PriorityQueue[T]) firstLeaf() int {
+	n := len(pq.items)
+	if n <= 1 {
+		return 0
+	}
+	return (n-2)/pq.d() + 1
+}
+
+func (pq *PriorityQueue[T]) init() {
+	for i := pq.firstLeaf() - 1; i >= 0; i-- {
+		pq.down(i, len(pq.items))
+	}
+	if pq.limit > 0 {
+		pq.winit()
+	}
+}
+
+func (pq *PriorityQueue[T]) push(it *Item[T]) {
+	pq.Push(it)
+	pq.up(len(pq.items) - 1)
+}
+
+func (pq *PriorityQueue[T]) pop() *Item[T] {
+	n := len(pq.items) - 1
+	pq.Swap(0, n)
+	pq.down(0, n)
+	return pq.Pop().(*Item[T])
+}
+
+func (pq *PriorityQueue[T]) remove(i int) *Item[T] {
+	n := len(pq.items) - 1
+	if n != i {
+		pq.Swap(i, n)
+		if !pq.down(i, n) {
+			pq.up(i)
+		}
+	}
+	return pq.Pop().(*Item[T])
+}
+
+func (pq *PriorityQueue[T]) fix(i int) {
+	it := pq.items[i]
+	if !pq.down(i, len(pq.items)) {
+		pq.up(i)
+	}
+	if pq.limit > 0 {
+		pq.wfix(it.windex)
+	}
+}
+
+func (pq *PriorityQueue[T]) up(j int) {
+	d := pq.d()
+	for j > 0 {
+		i := (j - 1) / d
+		if !pq.Less(j, i) {
+			break
+		}
+		pq.Swap(i, j)
+		j = i
+	}
+}
+
+// down sifts node i0 down within the first n nodes and reports whether it
+// moved.
+func (pq *PriorityQueue[T]) down(i0, n int) bool {
+	d := pq.d()
+	i := i0
+	for {
+		first := d*i + 1
+		if first >= n || first < 0 {
+			break
+		}
+		best := first
+		for c := first + 1; c < first+d && c < n; c++ {
+			if pq.Less(c, best) {
+				best = c
+			}
+		}
+		if !pq.Less(best, i) {
+			break
+		}
+		pq.Swap(i, best)
+		i = best
+	}
+	return i > i0
+}
@@ -0,0 +1,101 @@
+package priorityqueue
+
+// KeyedPriorityQueue is a priority queue whose entries are addressed by a
+// unique key, so a value's priority can be changed or the entry removed in
+// O(log n) without holding on to its Item. This is the structure Dijkstra
+// and A* need for decrease-key.
+type KeyedPriorityQueue[K comparable, T any] struct {
+	pq    *PriorityQueue[keyed[K, T]]
+	items map[K]*Item[keyed[K, T]]
+}
+
+type keyed[K comparable, T any] struct {
+	key   K
+	value T
+}
+
+// NewKeyed creates a new keyed priority queue with a custom less function.
+func NewKeyed[K comparable, T any](less func(a, b T) bool) *KeyedPriorityQueue[K, T] {
+	return &KeyedPriorityQueue[K, T]{
+		pq:    New(func(a, b keyed[K, T]) bool { return less(a.value, b.value) }),
+		items: make(map[K]*Item[keyed[K, T]]),
+	}
+}
+
+// Push adds value under key. If key is already present its value is
+// replaced and its position updated.
+func (q *KeyedPriorityQueue[K, T]) Push(key K, value T) {
+	if it, ok := q.items[key]; ok {
+		q.pq.UpdateValue(it, keyed[K, T]{key, value})
+		return
+	}
+	q.items[key] = q.pq.PushAndReturnItem(keyed[K, T]{key, value})
+}
+
+// Update replaces the value under key and restores the heap order. It
+// reports false if key is not present.
+func (q *KeyedPriorityQueue[K, T]) Update(key K, value T) bool {
+	it, ok := q.items[key]
+	if !ok {
+		return false
+	}
+	return q.pq.UpdateValue(it, keyed[K, T]{key, value})
+}
+
+// UpdatePriority restores the heap order after the value under key has been
+// changed in place, for example through a pointer. It reports false if key
+// is not present.
+func (q *KeyedPriorityQueue[K, T]) UpdatePriority(key K) bool {
+	it, ok := q.items[key]
+	if !ok {
+		return false
+	}
+	return q.pq.UpdateItem(it)
+}
+
+// Remove removes the entry under key and returns its value.
+func (q *KeyedPriorityQueue[K, T]) Remove(key K) (T, bool) {
+	it, ok := q.items[key]
+	if !ok {
+		var zero T
+		return zero, false
+	}
+	delete(q.items, key)
+	e, _ := q.pq.RemoveItem(it)
+	return e.value, true
+}
+
+// Contains reports whether key is present.
+func (q *KeyedPriorityQueue[K, T]) Contains(key K) bool {
+	_, ok := q.items[key]
+	return ok
+}
+
+// Get returns the value under key.
+func (q *KeyedPriorityQueue[K, T]) Get(key K) (T, bool) {
+	if it, ok := q.items[key]; ok {
+		return it.Value.value, true
+	}
+	var zero T
+	return zero, false
+}
+
+// Pop removes and returns the top-priority entry.
+func (q *KeyedPriorityQueue[K, T]) Pop() (K, T, bool) {
+	e, ok := q.pq.PopValue()
+	if ok {
+		delete(q.items, e.key)
+	}
+	return e.key, e.value, ok
+}
+
+// Peek returns the top-priority entry without removing it.
+func (q *KeyedPriorityQueue[K, T]) Peek() (K, T, bool) {
+	e, ok := q.pq.Peek()
+	return e.key, e.value, ok
+}
+
+// Len returns the number of entries.
+func (q *KeyedPriorityQueue[K, T]) Len() int {
+	return q.pq.Len()
+}
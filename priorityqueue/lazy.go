@@ -0,0 +1,44 @@
+package priorityqueue
+
+// MarkDeleted flags it as deleted in O(1) instead of removing it from the
+// heap. Deleted items stay in place until they reach the top, where pops and
+// peeks discard them, or until Compact purges them all at once. This keeps
+// high-rate cancellation cheap. It reports false if it is not in the queue
+// or already marked.
+func (pq *PriorityQueue[T]) MarkDeleted(it *Item[T]) bool {
+	if !pq.contains(it) {
+		return false
+	}
+	it.dead = true
+	pq.dead++
+	return true
+}
+
+// Compact physically removes all items marked deleted and rebuilds the heap
+// in O(n).
+func (pq *PriorityQueue[T]) Compact() {
+	if pq.dead == 0 {
+		return
+	}
+	live := pq.items[:0]
+	for _, it := range pq.items {
+		if it.dead {
+			it.index = -1
+			continue
+		}
+		it.index = len(live)
+		live = append(live, it)
+	}
+	clear(pq.items[len(live):])
+	pq.items = live
+	pq.dead = 0
+	pq.init()
+}
+
+// skipDead discards deleted items from the top of the heap.
+func (pq *PriorityQueue[T]) skipDead() {
+	for pq.dead > 0 && len(pq.items) > 0 && pq.items[0].dead {
+		pq.pop()
+		pq.dead--
+	}
+}
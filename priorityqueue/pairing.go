@@ -0,0 +1,235 @@
+package priorityqueue
+
+import "iter"
+
+// Queue is the API shared by the priority queue implementations in this
+// package, so code can pick PriorityQueue or PairingHeap at construction and
+// use either through it.
+type Queue[T any] interface {
+	PushValue(value T)
+	PopValue() (T, bool)
+	Peek() (T, bool)
+	Len() int
+}
+
+var (
+	_ Queue[int] = (*PriorityQueue[int])(nil)
+	_ Queue[int] = (*PairingHeap[int])(nil)
+)
+
+// PairingHeap is a generic, non-thread-safe priority queue backed by a
+// pairing heap. Push, Merge and improving a value's priority are O(1), and
+// Pop is O(log n) amortized, which suits workloads dominated by merges and
+// decrease-key, such as graph search and event simulation. PriorityQueue is
+// usually faster when pops dominate.
+type PairingHeap[T any] struct {
+	root *PairingNode[T]
+	len  int
+	less func(a, b T) bool
+}
+
+// PairingNode is the handle of a value in a PairingHeap, returned by
+// PushAndReturnItem for use with UpdateValue, UpdateItem and RemoveItem.
+type PairingNode[T any] struct {
+	Value   T
+	child   *PairingNode[T] // leftmost child
+	sibling *PairingNode[T] // next sibling to the right
+	prev    *PairingNode[T] // left sibling, or parent for a leftmost child
+	in      bool            // whether the node is in a heap
+}
+
+// NewPairing creates a new pairing heap with a custom less function.
+func NewPairing[T any](less func(a, b T) bool) *PairingHeap[T] {
+	return &PairingHeap[T]{less: less}
+}
+
+// PushValue adds a Value to the heap.
+func (h *PairingHeap[T]) PushValue(value T) {
+	h.PushAndReturnItem(value)
+}
+
+// PushAndReturnItem adds a Value to the heap and returns its node.
+func (h *PairingHeap[T]) PushAndReturnItem(value T) *PairingNode[T] {
+	n := &PairingNode[T]{Value: value, in: true}
+	h.root = h.meld(h.root, n)
+	h.len++
+	return n
+}
+
+// PopValue removes and returns the top-priority Value.
+func (h *PairingHeap[T]) PopValue() (T, bool) {
+	if h.root == nil {
+		var zero T
+		return zero, false
+	}
+	r := h.root
+	h.root = h.mergePairs(r.child)
+	h.len--
+	r.child, r.in = nil, false
+	return r.Value, true
+}
+
+// Peek returns the top-priority Value without removing it.
+func (h *PairingHeap[T]) Peek() (T, bool) {
+	if h.root == nil {
+		var zero T
+		return zero, false
+	}
+	return h.root.Value, true
+}
+
+// PeekValue returns the top-priority Value without removing it.
+func (h *PairingHeap[T]) PeekValue() (T, bool) {
+	return h.Peek()
+}
+
+// Len returns the number of items.
+func (h *PairingHeap[T]) Len() int {
+	return h.len
+}
+
+// UpdateValue replaces n.Value with value and restores the heap order. When
+// value has at least n's old priority this is O(1). It reports false if n is
+// not in a heap; n must belong to h or to a heap merged into h.
+func (h *PairingHeap[T]) UpdateValue(n *PairingNode[T], value T) bool {
+	if !n.in {
+		return false
+	}
+	improved := !h.less(n.Value, value)
+	n.Value = value
+	if !improved {
+		h.reinsert(n)
+	} else if n != h.root {
+		h.cut(n)
+		h.root = h.meld(h.root, n)
+	}
+	return true
+}
+
+// UpdateItem restores the heap order after the priority-relevant fields of
+// n.Value have changed in place. Since the direction of the change is
+// unknown it costs as much as a removal. It reports false if n is not in a
+// heap.
+func (h *PairingHeap[T]) UpdateItem(n *PairingNode[T]) bool {
+	if !n.in {
+		return false
+	}
+	h.reinsert(n)
+	return true
+}
+
+// RemoveItem removes n from the heap and returns its value.
+func (h *PairingHeap[T]) RemoveItem(n *PairingNode[T]) (T, bool) {
+	if !n.in {
+		var zero T
+		return zero, false
+	}
+	if n == h.root {
+		return h.PopValue()
+	}
+	h.cut(n)
+	h.root = h.meld(h.root, h.mergePairs(n.child))
+	h.len--
+	n.child, n.in = nil, false
+	return n.Value, true
+}
+
+// Merge moves all items of other into h in O(1), leaving other empty. Nodes
+// of other now belong to h.
+func (h *PairingHeap[T]) Merge(other *PairingHeap[T]) {
+	if other == h {
+		return
+	}
+	h.root = h.meld(h.root, other.root)
+	h.len += other.len
+	other.root, other.len = nil, 0
+}
+
+// Drain returns an iterator that pops and yields Values in priority order
+// until the heap is empty. Stopping early leaves the rest in the heap.
+func (h *PairingHeap[T]) Drain() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for h.root != nil {
+			v, _ := h.PopValue()
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// reinsert moves n, whose priority may have changed either way, to a valid
+// position: its children go back into the heap and n is pushed alone.
+func (h *PairingHeap[T]) reinsert(n *PairingNode[T]) {
+	if n == h.root {
+		h.root = h.mergePairs(n.child)
+	} else {
+		h.cut(n)
+		h.root = h.meld(h.root, h.mergePairs(n.child))
+	}
+	n.child = nil
+	h.root = h.meld(h.root, n)
+}
+
+// cut detaches the subtree rooted at n, which must not be the root.
+func (h *PairingHeap[T]) cut(n *PairingNode[T]) {
+	if n.prev.child == n {
+		n.prev.child = n.sibling
+	} else {
+		n.prev.sibling = n.sibling
+	}
+	if n.sibling != nil {
+		n.sibling.prev = n.prev
+	}
+	n.prev, n.sibling = nil, nil
+}
+
+// meld joins two heap roots and returns the new root.
+func (h *PairingHeap[T]) meld(a, b *PairingNode[T]) *PairingNode[T] {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if h.less(b.Value, a.Value) {
+		a, b = b, a
+	}
+	b.prev = a
+	b.sibling = a.child
+	if a.child != nil {
+		a.child.prev = b
+	}
+	a.child = b
+	a.prev, a.sibling = nil, nil
+	return a
+}
+
+// mergePairs combines a list of sibling subtrees into one with the standard
+// two-pass scheme: meld pairs left to right, then fold the results right to
+// left. The first pass builds its results in reverse through sibling
+// pointers, so no allocation is needed.
+func (h *PairingHeap[T]) mergePairs(first *PairingNode[T]) *PairingNode[T] {
+	var acc *PairingNode[T]
+	for first != nil {
+		a, b := first, first.sibling
+		if b != nil {
+			first = b.sibling
+			b.prev, b.sibling = nil, nil
+		} else {
+			first = nil
+		}
+		a.prev, a.sibling = nil, nil
+		m := h.meld(a, b)
+		m.sibling = acc
+		acc = m
+	}
+	var root *PairingNode[T]
+	for acc != nil {
+		next := acc.sibling
+		acc.sibling = nil
+		root = h.meld(acc, root)
+		acc = next
+	}
+	return root
+}
@@ -1,8 +1,9 @@
 package priorityqueue
 
 import (
-	"container/heap"
+	"cmp"
 	"fmt"
+	"iter"
 	"strings"
 )
 
@@ -10,24 +11,97 @@ import (
 type PriorityQueue[T any] struct {
 	items []*Item[T]
 	less  func(a, b T) bool
+	limit int // maximum length, 0 if unbounded
+	// worsts holds the items worst first in bounded queues, see bounded.go.
+	worsts []*Item[T]
+
+	stable bool   // break ties by insertion order, see NewStable
+	seq    uint64 // sequence number of the next item
+	arity  int    // children per node, 0 for the default of 2
+	dead   int    // number of items marked deleted, see MarkDeleted
 }
 
 type Item[T any] struct {
-	Value T
-	index int // internal index
+	Value  T
+	index  int    // internal index
+	windex int    // index in worsts, for bounded queues
+	seq    uint64 // insertion order, used by stable queues
+	dead   bool   // marked deleted, skipped by pops
 }
 
 // New creates a new priority queue with a custom less function.
 func New[T any](less func(a, b T) bool) *PriorityQueue[T] {
 	pq := &PriorityQueue[T]{less: less, items: []*Item[T]{}}
-	heap.Init(pq)
+	pq.init()
+	return pq
+}
+
+// NewStable creates a new priority queue in which values of equal priority,
+// where neither less(a, b) nor less(b, a) holds, pop in the order they were
+// pushed. Each item carries a sequence number that breaks the tie.
+func NewStable[T any](less func(a, b T) bool) *PriorityQueue[T] {
+	pq := New(less)
+	pq.stable = true
+	return pq
+}
+
+// NewFromSlice creates a new priority queue holding values, built with a
+// single O(n) bottom-up heapify instead of n pushes. values is not retained.
+func NewFromSlice[T any](values []T, less func(a, b T) bool) *PriorityQueue[T] {
+	pq := &PriorityQueue[T]{less: less, items: make([]*Item[T], len(values))}
+	for i, v := range values {
+		pq.items[i] = pq.newItem(v)
+		pq.items[i].index = i
+	}
+	pq.init()
+	return pq
+}
+
+// NewBounded creates a priority queue that keeps only the best k values
+// according to less: once it holds k values, each push evicts the worst
+// value. The queue tracks its worst value in a second heap, so a push costs
+// O(log k) and a value that does not make the cut is rejected in O(1). This
+// gives streaming top-K selection without a separate trim step. It panics if
+// k is not positive.
+func NewBounded[T any](k int, less func(a, b T) bool) *PriorityQueue[T] {
+	if k <= 0 {
+		panic("priorityqueue: bounded capacity must be positive")
+	}
+	pq := New(less)
+	pq.limit = k
 	return pq
 }
 
-// Len returns the number of items.
-func (pq PriorityQueue[T]) Len() int { return len(pq.items) }
+// MaxLen returns the capacity given to NewBounded, or 0 if the queue is
+// unbounded.
+func (pq *PriorityQueue[T]) MaxLen() int {
+	return pq.limit
+}
+
+// NewDary creates a new priority queue laid out as a d-ary heap; see
+// SetArity.
+func NewDary[T any](d int, less func(a, b T) bool) *PriorityQueue[T] {
+	pq := New(less)
+	pq.SetArity(d)
+	return pq
+}
+
+// NewMin creates a new priority queue that pops the smallest value first.
+func NewMin[T cmp.Ordered]() *PriorityQueue[T] {
+	return New(cmp.Less[T])
+}
+
+// NewMax creates a new priority queue that pops the largest value first.
+func NewMax[T cmp.Ordered]() *PriorityQueue[T] {
+	return New(func(a, b T) bool { return cmp.Less(b, a) })
+}
+
+// Len returns the number of items, not counting items marked deleted. The
+// heap code itself works on all stored items, so once items are marked
+// deleted the queue must not be driven through container/heap.
+func (pq PriorityQueue[T]) Len() int { return len(pq.items) - pq.dead }
 func (pq PriorityQueue[T]) Less(i, j int) bool {
-	return pq.less(pq.items[i].Value, pq.items[j].Value)
+	return pq.itemLess(pq.items[i], pq.items[j])
 }
 func (pq PriorityQueue[T]) Swap(i, j int) {
 	pq.items[i], pq.items[j] = pq.items[j], pq.items[i]
@@ -38,12 +112,18 @@ func (pq *PriorityQueue[T]) Push(x any) {
 	it := x.(*Item[T])
 	it.index = len(pq.items)
 	pq.items = append(pq.items, it)
+	if pq.limit > 0 {
+		pq.wpush(it)
+	}
 }
 func (pq *PriorityQueue[T]) Pop() any {
 	n := len(pq.items)
 	it := pq.items[n-1]
 	it.index = -1
 	pq.items = pq.items[:n-1]
+	if pq.limit > 0 {
+		pq.wremove(it)
+	}
 	return it
 }
 
@@ -52,34 +132,264 @@ func (pq *PriorityQueue[T]) PushValue(value T) {
 	pq.PushAndReturnItem(value)
 }
 
+// PushAndReturnItem adds a Value to the queue and returns its Item, for use
+// with UpdateItem and RemoveItem. On a full bounded queue it returns nil if
+// value did not make the cut.
 func (pq *PriorityQueue[T]) PushAndReturnItem(value T) *Item[T] {
-	it := &Item[T]{Value: value}
-	heap.Push(pq, it)
+	it := pq.newItem(value)
+	if evicted := pq.pushItem(it); evicted == it {
+		return nil
+	}
 	return it
 }
 
+// PushEvict adds a Value to the queue. On a full bounded queue the worst
+// value, which may be value itself, is dropped and returned with true.
+func (pq *PriorityQueue[T]) PushEvict(value T) (T, bool) {
+	if evicted := pq.pushItem(pq.newItem(value)); evicted != nil {
+		return evicted.Value, true
+	}
+	var zero T
+	return zero, false
+}
+
+// pushItem adds it to the heap, enforcing the bound. It returns the evicted
+// item, or nil if nothing was evicted.
+func (pq *PriorityQueue[T]) pushItem(it *Item[T]) *Item[T] {
+	if pq.limit > 0 && len(pq.items) >= pq.limit && pq.dead > 0 {
+		pq.Compact()
+	}
+	if pq.limit == 0 || len(pq.items) < pq.limit {
+		pq.push(it)
+		return nil
+	}
+	w := pq.worsts[0]
+	if !pq.itemLess(it, w) {
+		it.index = -1
+		return it
+	}
+	pq.replace(w, it)
+	pq.fix(it.index)
+	return w
+}
+
 func (pq *PriorityQueue[T]) RemoveItem(it *Item[T]) (T, bool) {
-	if it.index < 0 || it.index >= pq.Len() {
+	if !pq.contains(it) {
 		var zero T
 		return zero, false
 	}
-	removed := heap.Remove(pq, it.index).(*Item[T])
+	removed := pq.remove(it.index)
 	return removed.Value, true
 }
 
+// UpdateItem restores the heap order after the priority-relevant fields of
+// it.Value have changed in place, in O(log n). It reports false if it is not
+// in the queue.
+func (pq *PriorityQueue[T]) UpdateItem(it *Item[T]) bool {
+	if !pq.contains(it) {
+		return false
+	}
+	pq.fix(it.index)
+	return true
+}
+
+// UpdateValue replaces it.Value with value and restores the heap order. It
+// reports false, leaving it untouched, if it is not in the queue.
+func (pq *PriorityQueue[T]) UpdateValue(it *Item[T], value T) bool {
+	if !pq.contains(it) {
+		return false
+	}
+	it.Value = value
+	pq.fix(it.index)
+	return true
+}
+
+// newItem returns an item for value, stamped with the next sequence number.
+func (pq *PriorityQueue[T]) newItem(value T) *Item[T] {
+	it := &Item[T]{Value: value, seq: pq.seq}
+	pq.seq++
+	return it
+}
+
+// itemLess reports whether a has priority over b.
+func (pq *PriorityQueue[T]) itemLess(a, b *Item[T]) bool {
+	if pq.less(a.Value, b.Value) {
+		return true
+	}
+	return pq.stable && !pq.less(b.Value, a.Value) && a.seq < b.seq
+}
+
+// contains reports whether it is currently stored in pq and not marked
+// deleted.
+func (pq *PriorityQueue[T]) contains(it *Item[T]) bool {
+	return it.index >= 0 && it.index < len(pq.items) && pq.items[it.index] == it && !it.dead
+}
+
 // Pop removes and returns the top-priority Value.
 func (pq *PriorityQueue[T]) PopValue() (T, bool) {
-	if pq.Len() == 0 {
+	pq.skipDead()
+	if len(pq.items) == 0 {
 		var zero T
 		return zero, false
 	}
-	it := heap.Pop(pq).(*Item[T])
+	it := pq.pop()
 	return it.Value, true
 }
 
+// Clone returns a copy of the queue with fresh Items in the same heap order,
+// so the copy can be consumed without affecting pq. Values are copied by
+// assignment; handles to pq's items do not refer to the copy.
+func (pq *PriorityQueue[T]) Clone() *PriorityQueue[T] {
+	c := *pq
+	c.items = make([]*Item[T], len(pq.items))
+	if pq.limit > 0 {
+		c.worsts = make([]*Item[T], len(pq.worsts))
+	}
+	for i, it := range pq.items {
+		cp := *it
+		c.items[i] = &cp
+		if pq.limit > 0 {
+			c.worsts[cp.windex] = &cp
+		}
+	}
+	return &c
+}
+
+// Merge moves all items of other into pq, leaving other empty, and restores
+// the heap with a single O(n) heapify. Items keep their identity, so handles
+// returned by other's PushAndReturnItem now refer to pq. In a stable queue
+// other's items order after pq's among equals. A bounded pq instead takes
+// other's items one push at a time, dropping those that do not fit and any
+// marked deleted.
+func (pq *PriorityQueue[T]) Merge(other *PriorityQueue[T]) {
+	if other == pq {
+		return
+	}
+	items := other.items
+	other.items = nil
+	for _, it := range items {
+		it.seq += pq.seq
+	}
+	pq.seq += other.seq
+	dead := other.dead
+	other.dead = 0
+	if pq.limit > 0 {
+		// Drop marked items rather than pushing them: pushItem may
+		// Compact, which would lose count of dead items still to come.
+		for _, it := range items {
+			if it.dead {
+				it.index = -1
+				continue
+			}
+			pq.pushItem(it)
+		}
+		return
+	}
+	pq.dead += dead
+	for _, it := range items {
+		it.index = len(pq.items)
+		pq.items = append(pq.items, it)
+	}
+	pq.init()
+}
+
+// PopN removes and returns up to n top-priority Values, best first.
+func (pq *PriorityQueue[T]) PopN(n int) []T {
+	n = max(min(n, pq.Len()), 0)
+	out := make([]T, n)
+	for i := range out {
+		out[i], _ = pq.PopValue()
+	}
+	return out
+}
+
+// Drain returns an iterator that pops and yields Values in priority order
+// until the queue is empty. Stopping early leaves the rest in the queue.
+func (pq *PriorityQueue[T]) Drain() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for {
+			v, ok := pq.PopValue()
+			if !ok || !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// PopAll returns an iterator that consumes the queue in priority order. It
+// is the same as Drain, named to pair with Ordered.
+func (pq *PriorityQueue[T]) PopAll() iter.Seq[T] {
+	return pq.Drain()
+}
+
+// Ordered returns an iterator over the Values in priority order that leaves
+// the queue untouched. It works on a copy taken when iteration starts, so
+// the queue may be modified while ranging.
+func (pq *PriorityQueue[T]) Ordered() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		pq.Clone().Drain()(yield)
+	}
+}
+
+// PeekN returns up to n top-priority Values, best first, without removing
+// them. Rather than popping a copy, it walks the heap best-first from the
+// root, so it costs O(n log n) regardless of the queue's size.
+func (pq *PriorityQueue[T]) PeekN(n int) []T {
+	n = max(min(n, pq.Len()), 0)
+	out := make([]T, 0, n)
+	if n == 0 {
+		return out
+	}
+	frontier := New(func(a, b int) bool { return pq.itemLess(pq.items[a], pq.items[b]) })
+	frontier.PushValue(0)
+	for len(out) < n {
+		i, _ := frontier.PopValue()
+		if !pq.items[i].dead {
+			out = append(out, pq.items[i].Value)
+		}
+		for c := pq.child(i, 0); c <= pq.child(i, pq.d()-1) && c < len(pq.items); c++ {
+			frontier.PushValue(c)
+		}
+	}
+	return out
+}
+
+// PushPop pushes value and then pops and returns the top-priority Value,
+// with at most one sift. If value would be on top it is returned at once
+// without touching the heap.
+func (pq *PriorityQueue[T]) PushPop(value T) T {
+	pq.skipDead()
+	it := pq.newItem(value)
+	if len(pq.items) == 0 || !pq.itemLess(pq.items[0], it) {
+		return value
+	}
+	return pq.replaceTop(it)
+}
+
+// Replace pops the top-priority Value and then pushes value, with a single
+// sift. It reports false, and just pushes value, if the queue was empty.
+func (pq *PriorityQueue[T]) Replace(value T) (T, bool) {
+	pq.skipDead()
+	if len(pq.items) == 0 {
+		pq.PushValue(value)
+		var zero T
+		return zero, false
+	}
+	return pq.replaceTop(pq.newItem(value)), true
+}
+
+// replaceTop swaps it in for the top item and returns the old top value.
+func (pq *PriorityQueue[T]) replaceTop(it *Item[T]) T {
+	top := pq.items[0]
+	pq.replace(top, it)
+	pq.fix(0)
+	return top.Value
+}
+
 // Peek returns the top-priority Value without removing it.
 func (pq *PriorityQueue[T]) Peek() (T, bool) {
-	if pq.Len() == 0 {
+	pq.skipDead()
+	if len(pq.items) == 0 {
 		var zero T
 		return zero, false
 	}
@@ -95,11 +405,16 @@ func (pq *PriorityQueue[T]) PeekValue() (T, bool) {
 func (pq *PriorityQueue[T]) String() string {
 	var sb strings.Builder
 	sb.WriteString("PriorityQueue [")
-	for i, it := range pq.items {
+	i := 0
+	for _, it := range pq.items {
+		if it.dead {
+			continue
+		}
 		if i > 0 {
 			sb.WriteString(", ")
 		}
 		sb.WriteString(fmt.Sprintf("%v", it.Value))
+		i++
 	}
 	sb.WriteString("]")
 	return sb.String()
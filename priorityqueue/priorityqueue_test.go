@@ -1,8 +1,13 @@
 package priorityqueue
 
 import (
+	"context"
 	"fmt"
+	"math/rand/v2"
+	"slices"
+	"sync"
 	"testing"
+	"time"
 )
 
 func TestPriorityQueue(t *testing.T) {
@@ -60,3 +65,540 @@ func TestPriorityQueue_String(t *testing.T) {
 	})
 	fmt.Println(pq)
 }
+
+func TestUpdateItem(t *testing.T) {
+	pq := New[*El](func(a, b *El) bool { return a.Val < b.Val })
+	a := pq.PushAndReturnItem(&El{ID: 1, Val: 10})
+	b := pq.PushAndReturnItem(&El{ID: 2, Val: 20})
+	pq.PushValue(&El{ID: 3, Val: 30})
+
+	b.Value.Val = 5
+	if !pq.UpdateItem(b) {
+		t.Fatal("expected UpdateItem to succeed")
+	}
+	if v, _ := pq.Peek(); v.ID != 2 {
+		t.Errorf("expected 2 on top, got %v", v)
+	}
+	if !pq.UpdateValue(b, &El{ID: 2, Val: 40}) {
+		t.Fatal("expected UpdateValue to succeed")
+	}
+	var ids []int
+	for pq.Len() > 0 {
+		v, _ := pq.PopValue()
+		ids = append(ids, v.ID)
+	}
+	if fmt.Sprint(ids) != "[1 3 2]" {
+		t.Errorf("expected [1 3 2], got %v", ids)
+	}
+	if pq.UpdateItem(a) || pq.UpdateValue(a, &El{}) {
+		t.Errorf("expected updates of a popped item to fail")
+	}
+}
+
+func TestNewMinMax(t *testing.T) {
+	lo, hi := NewMin[string](), NewMax[string]()
+	for _, s := range []string{"pear", "apple", "fig"} {
+		lo.PushValue(s)
+		hi.PushValue(s)
+	}
+	if v, _ := lo.PopValue(); v != "apple" {
+		t.Errorf("expected apple, got %v", v)
+	}
+	if v, _ := hi.PopValue(); v != "pear" {
+		t.Errorf("expected pear, got %v", v)
+	}
+}
+
+func TestBounded(t *testing.T) {
+	pq := NewBounded(3, func(a, b int) bool { return a > b })
+	var evicted []int
+	for _, v := range []int{5, 1, 9, 7, 3, 8, 2} {
+		if e, ok := pq.PushEvict(v); ok {
+			evicted = append(evicted, e)
+		}
+	}
+	if fmt.Sprint(evicted) != "[1 3 5 2]" {
+		t.Errorf("expected evictions [1 3 5 2], got %v", evicted)
+	}
+	if it := pq.PushAndReturnItem(0); it != nil {
+		t.Errorf("expected no item for a value below the cut")
+	}
+	var top []int
+	for pq.Len() > 0 {
+		v, _ := pq.PopValue()
+		top = append(top, v)
+	}
+	if fmt.Sprint(top) != "[9 8 7]" {
+		t.Errorf("expected top 3 [9 8 7], got %v", top)
+	}
+	if pq.MaxLen() != 3 {
+		t.Errorf("expected MaxLen 3, got %v", pq.MaxLen())
+	}
+}
+
+func TestBoundedRandom(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	for _, d := range []int{2, 4} {
+		pq := NewBounded(50, func(a, b int) bool { return a > b })
+		pq.SetArity(d)
+		var kept []*Item[int]
+		for i := 0; i < 5000; i++ {
+			switch v := r.IntN(1000); {
+			case i%7 == 0 && len(kept) > 0:
+				j := r.IntN(len(kept))
+				pq.UpdateValue(kept[j], v)
+			case i%11 == 0 && len(kept) > 0:
+				pq.RemoveItem(kept[r.IntN(len(kept))])
+			case i%13 == 0:
+				pq.Replace(v)
+			default:
+				if it := pq.PushAndReturnItem(v); it != nil {
+					kept = append(kept, it)
+				}
+			}
+		}
+		want := slices.Collect(pq.Clone().Drain())
+		if !slices.IsSortedFunc(want, func(a, b int) int { return b - a }) || len(want) != pq.Len() {
+			t.Fatalf("arity %d: clone drained out of order: %v", d, want)
+		}
+		for i, it := range pq.worsts {
+			if it.windex != i || pq.items[it.index] != it || (i > 0 && pq.wless(i, (i-1)/2)) {
+				t.Fatalf("arity %d: worst-first heap broken at %d", d, i)
+			}
+		}
+		min := want[len(want)-1]
+		for v := 0; v < min; v++ {
+			if _, ok := pq.PushEvict(v); !ok {
+				t.Fatalf("arity %d: expected %d below the cut to be rejected", d, v)
+			}
+		}
+		if e, ok := pq.PushEvict(1000); !ok || e != min {
+			t.Errorf("arity %d: expected %d evicted, got %v", d, min, e)
+		}
+	}
+}
+
+func TestPushPopReplace(t *testing.T) {
+	pq := NewMin[int]()
+	if v := pq.PushPop(4); v != 4 || pq.Len() != 0 {
+		t.Errorf("expected PushPop on empty queue to return 4, got %v", v)
+	}
+	if _, ok := pq.Replace(5); ok || pq.Len() != 1 {
+		t.Errorf("expected Replace on empty queue to just push")
+	}
+	pq.PushValue(3)
+	pq.PushValue(8)
+	if v := pq.PushPop(1); v != 1 {
+		t.Errorf("expected PushPop to return the better pushed value 1, got %v", v)
+	}
+	if v := pq.PushPop(6); v != 3 {
+		t.Errorf("expected PushPop to return 3, got %v", v)
+	}
+	if v, ok := pq.Replace(2); !ok || v != 5 {
+		t.Errorf("expected Replace to return (5, true), got (%v, %v)", v, ok)
+	}
+	var rest []int
+	for pq.Len() > 0 {
+		v, _ := pq.PopValue()
+		rest = append(rest, v)
+	}
+	if fmt.Sprint(rest) != "[2 6 8]" {
+		t.Errorf("expected [2 6 8], got %v", rest)
+	}
+}
+
+func TestPopNDrain(t *testing.T) {
+	pq := NewMax[int]()
+	for _, v := range []int{4, 9, 1, 7, 3, 8} {
+		pq.PushValue(v)
+	}
+	if got := fmt.Sprint(pq.PopN(2)); got != "[9 8]" {
+		t.Errorf("expected [9 8], got %v", got)
+	}
+	var got []int
+	for v := range pq.Drain() {
+		if v < 3 {
+			break
+		}
+		got = append(got, v)
+	}
+	if fmt.Sprint(got) != "[7 4 3]" || pq.Len() != 0 {
+		t.Errorf("expected [7 4 3] and an empty queue, got %v and %v", got, pq)
+	}
+	if len(pq.PopN(5)) != 0 {
+		t.Errorf("expected PopN on empty queue to return nothing")
+	}
+}
+
+func TestNewFromSlice(t *testing.T) {
+	values := []int{5, 2, 8, 1, 9, 3}
+	pq := NewFromSlice(values, func(a, b int) bool { return a < b })
+	values[0] = 100
+	pq.PushValue(4)
+	if got := fmt.Sprint(pq.PopN(pq.Len())); got != "[1 2 3 4 5 8 9]" {
+		t.Errorf("expected [1 2 3 4 5 8 9], got %v", got)
+	}
+	if NewFromSlice(nil, func(a, b int) bool { return a < b }).Len() != 0 {
+		t.Errorf("expected empty queue")
+	}
+}
+
+func TestStable(t *testing.T) {
+	pq := NewStable(func(a, b *El) bool { return a.Val > b.Val })
+	for id, val := range []int{1, 2, 1, 2, 1, 2, 1} {
+		pq.PushValue(&El{ID: id, Val: val})
+	}
+	if v := pq.PushPop(&El{ID: 7, Val: 2}); v.ID != 1 {
+		t.Errorf("expected PushPop to return the earlier equal item 1, got %v", v)
+	}
+	var ids []int
+	for v := range pq.Drain() {
+		ids = append(ids, v.ID)
+	}
+	if fmt.Sprint(ids) != "[3 5 7 0 2 4 6]" {
+		t.Errorf("expected FIFO order among equals [3 5 7 0 2 4 6], got %v", ids)
+	}
+}
+
+func TestKeyedPriorityQueue(t *testing.T) {
+	q := NewKeyed[string](func(a, b int) bool { return a < b })
+	q.Push("a", 5)
+	q.Push("b", 3)
+	q.Push("c", 8)
+	q.Push("a", 9)
+	if !q.Update("c", 1) || q.Update("z", 0) {
+		t.Errorf("unexpected Update results")
+	}
+	if k, v, _ := q.Peek(); k != "c" || v != 1 {
+		t.Errorf("expected (c, 1) on top, got (%v, %v)", k, v)
+	}
+	if v, ok := q.Remove("b"); !ok || v != 3 || q.Contains("b") {
+		t.Errorf("expected b removed with 3, got (%v, %v)", v, ok)
+	}
+	if v, _ := q.Get("a"); v != 9 {
+		t.Errorf("expected a to be 9, got %v", v)
+	}
+	var keys []string
+	for q.Len() > 0 {
+		k, _, _ := q.Pop()
+		keys = append(keys, k)
+	}
+	if fmt.Sprint(keys) != "[c a]" || q.Contains("a") {
+		t.Errorf("expected [c a], got %v", keys)
+	}
+
+	dist := map[int]*int{}
+	p := NewKeyed[int](func(a, b *int) bool { return *a < *b })
+	for k, d := range []int{7, 4, 6} {
+		v := d
+		dist[k] = &v
+		p.Push(k, &v)
+	}
+	*dist[0] = 1
+	if !p.UpdatePriority(0) {
+		t.Fatal("expected UpdatePriority to succeed")
+	}
+	if k, _, _ := p.Pop(); k != 0 {
+		t.Errorf("expected key 0 after decrease-key, got %v", k)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	a := NewFromSlice([]int{5, 1, 9}, func(a, b int) bool { return a < b })
+	b := NewFromSlice([]int{4, 8, 2}, func(a, b int) bool { return a < b })
+	it := b.PushAndReturnItem(7)
+	a.Merge(b)
+	if b.Len() != 0 || a.Len() != 7 {
+		t.Fatalf("expected 7 items merged, got %v and %v", a.Len(), b.Len())
+	}
+	if !a.UpdateValue(it, 0) {
+		t.Errorf("expected merged item handle to stay valid")
+	}
+	if got := fmt.Sprint(a.PopN(7)); got != "[0 1 2 4 5 8 9]" {
+		t.Errorf("expected [0 1 2 4 5 8 9], got %v", got)
+	}
+
+	s1 := NewStable(func(a, b *El) bool { return a.Val < b.Val })
+	s2 := NewStable(func(a, b *El) bool { return a.Val < b.Val })
+	s2.PushValue(&El{ID: 0})
+	s1.PushValue(&El{ID: 1})
+	s2.PushValue(&El{ID: 2})
+	s1.Merge(s2)
+	var ids []int
+	for v := range s1.Drain() {
+		ids = append(ids, v.ID)
+	}
+	if fmt.Sprint(ids) != "[1 0 2]" {
+		t.Errorf("expected [1 0 2], got %v", ids)
+	}
+}
+
+func TestClone(t *testing.T) {
+	pq := NewMax[int]()
+	for _, v := range []int{3, 7, 1} {
+		pq.PushValue(v)
+	}
+	it := pq.PushAndReturnItem(5)
+	c := pq.Clone()
+	if got := fmt.Sprint(c.PopN(2)); got != "[7 5]" {
+		t.Errorf("expected [7 5] from clone, got %v", got)
+	}
+	if c.UpdateValue(it, 10) {
+		t.Errorf("expected original handle not to refer to the clone")
+	}
+	if pq.Len() != 4 || pq.String() != "PriorityQueue [7, 5, 1, 3]" {
+		t.Errorf("expected original untouched, got %v", pq)
+	}
+}
+
+func TestPeekN(t *testing.T) {
+	pq := NewFromSlice([]int{8, 3, 5, 1, 9, 2, 7}, func(a, b int) bool { return a < b })
+	if got := fmt.Sprint(pq.PeekN(4)); got != "[1 2 3 5]" {
+		t.Errorf("expected [1 2 3 5], got %v", got)
+	}
+	if got := fmt.Sprint(pq.PeekN(20)); got != "[1 2 3 5 7 8 9]" {
+		t.Errorf("expected all values in order, got %v", got)
+	}
+	if pq.Len() != 7 || len(pq.PeekN(0)) != 0 {
+		t.Errorf("expected PeekN not to remove anything")
+	}
+}
+
+func TestOrderedPopAll(t *testing.T) {
+	pq := NewMin[int]()
+	for _, v := range []int{4, 2, 6, 1} {
+		pq.PushValue(v)
+	}
+	for v := range pq.Ordered() {
+		pq.PushValue(v * 10)
+	}
+	if got := fmt.Sprint(slices.Collect(pq.Ordered())); got != "[1 2 4 6 10 20 40 60]" {
+		t.Errorf("expected [1 2 4 6 10 20 40 60], got %v", got)
+	}
+	if pq.Len() != 8 {
+		t.Errorf("expected Ordered not to consume, got %v items", pq.Len())
+	}
+	if got := fmt.Sprint(slices.Collect(pq.PopAll())); got != "[1 2 4 6 10 20 40 60]" || pq.Len() != 0 {
+		t.Errorf("expected PopAll to consume in order, got %v", got)
+	}
+}
+
+func TestJSON(t *testing.T) {
+	pq := NewMax[int]()
+	for _, v := range []int{3, 9, 4, 7} {
+		pq.PushValue(v)
+	}
+	sorted, err := pq.MarshalJSONSorted()
+	if err != nil || string(sorted) != "[9,7,4,3]" {
+		t.Errorf("expected [9,7,4,3], got %s (%v)", sorted, err)
+	}
+	data, err := pq.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	restored, err := UnmarshalInto(data, func(a, b int) bool { return a > b })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := fmt.Sprint(restored.PopN(4)); got != "[9 7 4 3]" {
+		t.Errorf("expected [9 7 4 3], got %v", got)
+	}
+	if _, err := UnmarshalInto([]byte(`{"a":1}`), func(a, b int) bool { return a < b }); err == nil {
+		t.Errorf("expected an error for a non-array")
+	}
+}
+
+func TestSyncPriorityQueue(t *testing.T) {
+	s := NewSync(func(a, b int) bool { return a < b })
+	var wg sync.WaitGroup
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				s.PushValue(w*100 + i)
+			}
+		}()
+	}
+	wg.Wait()
+	if n := s.PushAndLen(-1); n != 401 {
+		t.Errorf("expected length 401, got %v", n)
+	}
+	if _, ok := s.PopIf(func(v int) bool { return v > 0 }); ok {
+		t.Errorf("expected PopIf to decline -1")
+	}
+	if v, ok := s.PopIf(func(v int) bool { return v < 0 }); !ok || v != -1 {
+		t.Errorf("expected (-1, true), got (%v, %v)", v, ok)
+	}
+	s.Do(func(pq *PriorityQueue[int]) { pq.PopN(398) })
+	if got := fmt.Sprint(s.PopN(5)); got != "[398 399]" {
+		t.Errorf("expected [398 399], got %v", got)
+	}
+}
+
+func TestBlockingPriorityQueue(t *testing.T) {
+	b := NewBlocking(func(a, b int) bool { return a < b })
+	ctx := context.Background()
+	got := make(chan int)
+	go func() {
+		v, err := b.Pop(ctx)
+		if err != nil {
+			t.Error(err)
+		}
+		got <- v
+	}()
+	time.Sleep(5 * time.Millisecond)
+	b.Push(3)
+	if v := <-got; v != 3 {
+		t.Errorf("expected 3, got %v", v)
+	}
+
+	short, cancel := context.WithTimeout(ctx, 5*time.Millisecond)
+	defer cancel()
+	if _, err := b.Pop(short); err != context.DeadlineExceeded {
+		t.Errorf("expected DeadlineExceeded, got %v", err)
+	}
+
+	b.Push(2)
+	b.Push(1)
+	b.Close()
+	if err := b.Push(0); err != ErrClosed {
+		t.Errorf("expected ErrClosed from Push, got %v", err)
+	}
+	var drained []int
+	for {
+		v, err := b.Pop(ctx)
+		if err == ErrClosed {
+			break
+		}
+		drained = append(drained, v)
+	}
+	if fmt.Sprint(drained) != "[1 2]" {
+		t.Errorf("expected [1 2] drained after Close, got %v", drained)
+	}
+}
+
+func TestArity(t *testing.T) {
+	values := make([]int, 200)
+	for i := range values {
+		values[i] = (i * 7919) % 211
+	}
+	want := slices.Sorted(slices.Values(values))
+	for _, d := range []int{2, 3, 4, 8} {
+		pq := NewDary(d, func(a, b int) bool { return a < b })
+		items := make([]*Item[int], 0, len(values))
+		for _, v := range values {
+			items = append(items, pq.PushAndReturnItem(v))
+		}
+		pq.RemoveItem(items[10])
+		pq.UpdateValue(items[20], -1)
+		pq.SetArity(d + 1)
+		got := pq.PopN(pq.Len())
+		exp := slices.Clone(want)
+		exp = slices.Delete(exp, slices.Index(exp, values[10]), slices.Index(exp, values[10])+1)
+		exp = slices.Delete(exp, slices.Index(exp, values[20]), slices.Index(exp, values[20])+1)
+		exp = append([]int{-1}, exp...)
+		if !slices.Equal(got, exp) {
+			t.Errorf("arity %d: unexpected pop order %v", d, got)
+		}
+	}
+	if a := New(func(a, b int) bool { return a < b }).Arity(); a != 2 {
+		t.Errorf("expected default arity 2, got %v", a)
+	}
+}
+
+func TestPairingHeap(t *testing.T) {
+	var q Queue[int] = NewPairing(func(a, b int) bool { return a < b })
+	h := q.(*PairingHeap[int])
+	values := make([]int, 100)
+	nodes := make([]*PairingNode[int], 100)
+	for i := range values {
+		values[i] = (i * 37) % 101
+		nodes[i] = h.PushAndReturnItem(values[i])
+	}
+	h.PopValue()
+	h.RemoveItem(nodes[5])
+	h.UpdateValue(nodes[7], -5)
+	h.UpdateValue(nodes[9], 500)
+	nodes[11].Value = -3
+	h.UpdateItem(nodes[11])
+
+	other := NewPairing(func(a, b int) bool { return a < b })
+	other.PushValue(-4)
+	h.Merge(other)
+	if other.Len() != 0 || h.Len() != 99 {
+		t.Fatalf("expected 99 items after merge, got %v", h.Len())
+	}
+	got := slices.Collect(h.Drain())
+	if !slices.IsSorted(got) || got[0] != -5 || got[1] != -4 || got[2] != -3 || got[len(got)-1] != 500 {
+		t.Errorf("unexpected drain order %v", got)
+	}
+	if _, ok := h.RemoveItem(nodes[5]); ok || h.UpdateItem(nodes[7]) {
+		t.Errorf("expected operations on removed nodes to fail")
+	}
+	if _, ok := q.Peek(); ok || q.Len() != 0 {
+		t.Errorf("expected empty heap")
+	}
+}
+
+func TestMarkDeleted(t *testing.T) {
+	pq := NewMin[int]()
+	items := make([]*Item[int], 10)
+	for i := range items {
+		items[i] = pq.PushAndReturnItem(i)
+	}
+	for _, i := range []int{0, 1, 4, 7} {
+		if !pq.MarkDeleted(items[i]) {
+			t.Fatalf("expected MarkDeleted(%d) to succeed", i)
+		}
+	}
+	if pq.MarkDeleted(items[4]) || pq.UpdateValue(items[4], -1) {
+		t.Errorf("expected a deleted item to be rejected")
+	}
+	if pq.Len() != 6 || len(pq.items) != 10 {
+		t.Errorf("expected 6 live items out of 10, got %v and %v", pq.Len(), len(pq.items))
+	}
+	if v, _ := pq.Peek(); v != 2 {
+		t.Errorf("expected 2 on top, got %v", v)
+	}
+	if got := fmt.Sprint(pq.PeekN(3)); got != "[2 3 5]" {
+		t.Errorf("expected PeekN [2 3 5], got %v", got)
+	}
+	pq.Compact()
+	if pq.Len() != 6 || len(pq.items) != 6 {
+		t.Errorf("expected 6 items after Compact, got %v", len(pq.items))
+	}
+	pq.MarkDeleted(items[9])
+	if got := fmt.Sprint(slices.Collect(pq.Drain())); got != "[2 3 5 6 8]" {
+		t.Errorf("expected [2 3 5 6 8], got %v", got)
+	}
+
+	pq.PushValue(1)
+	pq.MarkDeleted(pq.PushAndReturnItem(2))
+	var got []int
+	for pq.Len() > 0 {
+		v, _ := pq.PopValue()
+		got = append(got, v)
+	}
+	if fmt.Sprint(got) != "[1]" {
+		t.Errorf("expected a Len-driven drain to give [1], got %v", got)
+	}
+}
+
+func TestMergeMarkedIntoBounded(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	pq := NewBounded(2, less)
+	pq.PushValue(10)
+	pq.PushValue(20)
+	other := New(less)
+	cancelled := other.PushAndReturnItem(1)
+	other.PushValue(30)
+	other.MarkDeleted(cancelled)
+	pq.Merge(other)
+	if pq.Len() != 2 || other.Len() != 0 || cancelled.index != -1 {
+		t.Errorf("expected 2 live items and a detached handle, got %v, %v, %v", pq.Len(), other.Len(), cancelled.index)
+	}
+	if got := fmt.Sprint(slices.Collect(pq.Drain())); got != "[10 20]" {
+		t.Errorf("expected [10 20], got %v", got)
+	}
+}
@@ -0,0 +1,92 @@
+package priorityqueue
+
+import "sync"
+
+// SyncPriorityQueue is a PriorityQueue guarded by a mutex, safe for
+// concurrent use. Besides the usual single operations it offers a few
+// read-modify-write helpers that would race if composed from separate calls,
+// plus Do for anything else.
+type SyncPriorityQueue[T any] struct {
+	mu sync.Mutex
+	pq *PriorityQueue[T]
+}
+
+// NewSync creates a new empty thread-safe priority queue with a custom less
+// function.
+func NewSync[T any](less func(a, b T) bool) *SyncPriorityQueue[T] {
+	return &SyncPriorityQueue[T]{pq: New(less)}
+}
+
+// NewSyncFrom wraps pq. The caller must not use pq directly afterwards.
+func NewSyncFrom[T any](pq *PriorityQueue[T]) *SyncPriorityQueue[T] {
+	return &SyncPriorityQueue[T]{pq: pq}
+}
+
+// PushValue adds a Value to the queue.
+func (s *SyncPriorityQueue[T]) PushValue(value T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pq.PushValue(value)
+}
+
+// PushAndLen adds a Value to the queue and returns the resulting length.
+func (s *SyncPriorityQueue[T]) PushAndLen(value T) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pq.PushValue(value)
+	return s.pq.Len()
+}
+
+// PopValue removes and returns the top-priority Value.
+func (s *SyncPriorityQueue[T]) PopValue() (T, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.pq.PopValue()
+}
+
+// PopIf removes and returns the top-priority Value only if pred accepts it.
+func (s *SyncPriorityQueue[T]) PopIf(pred func(T) bool) (T, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if v, ok := s.pq.Peek(); !ok || !pred(v) {
+		var zero T
+		return zero, false
+	}
+	return s.pq.PopValue()
+}
+
+// PopN removes and returns up to n top-priority Values, best first.
+func (s *SyncPriorityQueue[T]) PopN(n int) []T {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.pq.PopN(n)
+}
+
+// Peek returns the top-priority Value without removing it.
+func (s *SyncPriorityQueue[T]) Peek() (T, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.pq.Peek()
+}
+
+// Len returns the number of items.
+func (s *SyncPriorityQueue[T]) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.pq.Len()
+}
+
+// String implements fmt.Stringer
+func (s *SyncPriorityQueue[T]) String() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.pq.String()
+}
+
+// Do runs f with exclusive access to the underlying queue, making any
+// sequence of operations atomic. f must not retain pq after returning.
+func (s *SyncPriorityQueue[T]) Do(f func(pq *PriorityQueue[T])) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f(s.pq)
+}